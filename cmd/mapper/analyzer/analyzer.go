@@ -0,0 +1,390 @@
+// Package analyzer reports the invalid map tags and the mapper interfaces that
+// cannot be generated, at vet time instead of go generate, e.g.
+//
+//	go vet -vettool=$(which mappervet) ./...
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/cmd/mapper/internal"
+	"golang.org/x/tools/go/analysis"
+)
+
+const doc = `check the map tags and mapper interfaces
+
+The analyzer reports the map tags that are malformed, that reference funcs or
+methods that are not found, or whose func does not accept nor return the field
+type. The interfaces listed with -type in the go:generate comments of the
+mapper, the interfaces annotated with //mapper:generate, and the //mapper:func
+vars, are checked for target fields without mapping, and for the errors that
+the generator would return.`
+
+var Analyzer = &analysis.Analyzer{
+	Name: "mapper",
+	Doc:  doc,
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	// The funcs of the tags are resolved from the type checked packages of
+	// the pass, instead of loading them again.
+	c := &checker{
+		pass:   pass,
+		pkgs:   internal.NewPackages(pass.Pkg),
+		fields: make(map[token.Pos]*ast.Field),
+	}
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if s, ok := n.(*ast.StructType); ok {
+				for _, field := range s.Fields.List {
+					c.checkTag(field)
+				}
+			}
+			return true
+		})
+	}
+
+	directives := mapper.NewDirectives(pass.Files)
+	for _, name := range interfaceNames(pass.Files, directives) {
+		obj := pass.Pkg.Scope().Lookup(name)
+		if obj == nil {
+			continue
+		}
+		if T, ok := obj.Type().Underlying().(*types.Interface); ok {
+			c.checkInterface(obj.Pos(), name, T, directives)
+		}
+	}
+	if T, ok := mapper.NewPrototypes(pass.Pkg, directives); ok {
+		c.checkInterface(pass.Files[0].Package, "", T, directives)
+	}
+	return nil, nil
+}
+
+type checker struct {
+	pass     *analysis.Pass
+	pkgs     *internal.Packages
+	fields   map[token.Pos]*ast.Field // The fields by the position of their names.
+	reported bool
+}
+
+func (c *checker) report(d analysis.Diagnostic) {
+	c.reported = true
+	c.pass.Report(d)
+}
+
+// checkTag reports the map tag of the field that is malformed, or whose func
+// cannot be resolved or does not match the field type.
+func (c *checker) checkTag(field *ast.Field) {
+	for _, name := range field.Names {
+		c.fields[name.Pos()] = field
+	}
+	if field.Tag == nil {
+		return
+	}
+	value, err := strconv.Unquote(field.Tag.Value)
+	if err != nil || !strings.Contains(value, `map:"`) {
+		return
+	}
+
+	tag, ok := newTag(value)
+	if !ok {
+		c.report(analysis.Diagnostic{
+			Pos:     field.Tag.Pos(),
+			End:     field.Tag.End(),
+			Message: fmt.Sprintf("malformed map tag %q", value),
+		})
+		return
+	}
+	if !tag.HasFunc() {
+		return
+	}
+
+	T := c.pass.TypesInfo.TypeOf(field.Type)
+	for _, name := range field.Names {
+		sf := mapper.StructField{
+			Name:     name.Name,
+			Pkg:      c.pass.Pkg.Name(),
+			PkgPath:  c.pass.Pkg.Path(),
+			Exported: name.IsExported(),
+			Tag:      tag,
+			Type:     T,
+		}
+		fn, err := c.pkgs.LoadTagFunc(sf)
+		if err != nil {
+			c.report(analysis.Diagnostic{
+				Pos:     field.Tag.Pos(),
+				End:     field.Tag.End(),
+				Message: fmt.Sprintf("map tag of field %q references unknown func: %s", name.Name, oneLine(err)),
+			})
+			return
+		}
+
+		// The tag of the target field converts the source field to the field
+		// type, and the tag of the source field converts the field type.
+		if !mapper.IsUnderlyingIdentical(fn.To.Type, T) && !mapper.IsUnderlyingIdentical(fn.From.Type, T) {
+			c.report(analysis.Diagnostic{
+				Pos: field.Tag.Pos(),
+				End: field.Tag.End(),
+				Message: fmt.Sprintf("map tag of field %q references %s, which neither accepts nor returns %s",
+					name.Name,
+					internal.PrettyFuncSignature(fn.Fn),
+					types.TypeString(T, (*types.Package).Name),
+				),
+			})
+			return
+		}
+	}
+}
+
+// checkInterface reports the target fields of the methods without mapping,
+// followed by the first error of the generator, if any.
+func (c *checker) checkInterface(pos token.Pos, name string, T *types.Interface, directives mapper.Directives) {
+	if c.reported {
+		// The generator fails on the first invalid tag, which is already
+		// reported.
+		return
+	}
+
+	methods := mapper.NewInterfaceMethods(T)
+	names := make([]string, 0, len(methods))
+	for key := range methods {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	for _, key := range names {
+		fn := methods[key]
+		fv, err := newFuncVisitor(c.pkgs, name, fn, directives)
+		if err != nil {
+			// Reported by the generator below.
+			continue
+		}
+		// The target fields without mapping are left at their zero value.
+		if internal.IsLenient(directives, name, methodKey(name, fn)) {
+			continue
+		}
+		for _, field := range fv.UnmappedFields() {
+			c.reportUnmapped(fn, field)
+		}
+	}
+	if c.reported {
+		return
+	}
+
+	if err := validate(c.pkgs, name, T, c.pass, directives); err != nil {
+		label := "interface " + name
+		if name == "" {
+			label = "//mapper:func vars"
+		}
+		c.report(analysis.Diagnostic{
+			Pos:     pos,
+			Message: fmt.Sprintf("cannot generate the mapper for %s: %s", label, oneLine(err)),
+		})
+	}
+}
+
+// reportUnmapped reports the target field without mapping, and suggests to
+// ignore the field.
+func (c *checker) reportUnmapped(fn *mapper.Func, name string) {
+	d := analysis.Diagnostic{
+		Pos:     fn.Fn.Pos(),
+		Message: fmt.Sprintf("no mapping found for field %q in %s", name, internal.PrettyFuncSignature(fn.Fn)),
+	}
+
+	// The field is reported at its declaration, when it is in this package.
+	pos := fieldPos(fn.To.Type, name)
+	if field, ok := c.fields[pos]; ok {
+		d.Pos = pos
+		d.Related = []analysis.RelatedInformation{{
+			Pos:     fn.Fn.Pos(),
+			Message: "mapped by " + fn.Name,
+		}}
+		if fix, ok := ignoreFix(field); ok {
+			d.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+	}
+	c.report(d)
+}
+
+// ignoreFix adds `map:"-"` to the tag of the field.
+func ignoreFix(field *ast.Field) (analysis.SuggestedFix, bool) {
+	fix := analysis.SuggestedFix{Message: `Ignore the field with map:"-"`}
+	if field.Tag == nil {
+		fix.TextEdits = []analysis.TextEdit{{
+			Pos:     field.Type.End(),
+			End:     field.Type.End(),
+			NewText: []byte(" `map:\"-\"`"),
+		}}
+		return fix, true
+	}
+
+	// Only the raw string tags can be extended.
+	if !strings.HasPrefix(field.Tag.Value, "`") {
+		return fix, false
+	}
+	value := strings.TrimSuffix(field.Tag.Value, "`")
+	fix.TextEdits = []analysis.TextEdit{{
+		Pos:     field.Tag.Pos(),
+		End:     field.Tag.End(),
+		NewText: []byte(value + " map:\"-\"`"),
+	}}
+	return fix, true
+}
+
+// newFuncVisitor parses the method, with the directives applied.
+func newFuncVisitor(pkgs *internal.Packages, name string, fn *mapper.Func, directives mapper.Directives) (fv *internal.FuncVisitor, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	fv = internal.NewFuncVisitor(pkgs)
+	fv.Visit(fn.Fn)
+
+	key := methodKey(name, fn)
+	configured := append(directives.Lookup(key, internal.FieldDirective), directives.Lookup(key, internal.IgnoreDirective)...)
+	if len(configured) == 0 {
+		return fv, nil
+	}
+	tags, err := internal.NewMethodTags(configured, fn.PkgPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := fv.Result.Override(tags); err != nil {
+		return nil, err
+	}
+	return fv, nil
+}
+
+// methodKey returns the key of the directives of the method, e.g. Mapper.ToB,
+// or the var name of the //mapper:func vars.
+func methodKey(name string, fn *mapper.Func) string {
+	if name == "" {
+		return fn.Name
+	}
+	return name + "." + fn.Name
+}
+
+// validate runs the validation of the generator on the interface.
+func validate(pkgs *internal.Packages, name string, T *types.Interface, pass *analysis.Pass, directives mapper.Directives) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	converters := internal.DefaultConverters().Extend()
+	converters.AddAnnotated(pass.Pkg, pass.Files)
+	for _, pkgPath := range generateFlags(pass.Files, "converters") {
+		// The load errors are reported, instead of exiting the driver.
+		if err := converters.LoadAnnotated(pkgPath); err != nil {
+			return fmt.Errorf("cannot load the converters of %s: %w", pkgPath, err)
+		}
+	}
+	_ = internal.NewInterfaceVisitor(name, T, pkgs, converters, internal.NewEnums(pass.Pkg, pass.Files), directives)
+	return nil
+}
+
+// interfaceNames returns the names of the interfaces listed with -type in the
+// go:generate comments, followed by the types annotated with
+// //mapper:generate, which are generated by mapper ./... without flags.
+func interfaceNames(files []*ast.File, directives mapper.Directives) []string {
+	seen := make(map[string]bool)
+	var result []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+
+	for _, name := range generateFlags(files, "type") {
+		add(name)
+	}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				name := spec.(*ast.TypeSpec).Name.Name
+				if directives.Has(name, mapper.GenerateDirective) {
+					add(name)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// generateFlags returns the values of the flag passed to the mapper in the
+// go:generate comments, e.g. Mapper for -type:
+//
+//	//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+func generateFlags(files []*ast.File, name string) []string {
+	var result []string
+	for _, f := range files {
+		for _, group := range f.Comments {
+			for _, comment := range group.List {
+				if !strings.HasPrefix(comment.Text, "//go:generate ") || !strings.Contains(comment.Text, "mapper/cmd/mapper") {
+					continue
+				}
+				args := strings.Fields(comment.Text)
+				for i, arg := range args {
+					arg = strings.TrimLeft(arg, "-")
+					switch {
+					case arg == name && i+1 < len(args):
+						result = append(result, strings.Split(args[i+1], ",")...)
+					case strings.HasPrefix(arg, name+"="):
+						result = append(result, strings.Split(strings.TrimPrefix(arg, name+"="), ",")...)
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// newTag is mapper.NewTag, which panics on some malformed tags.
+func newTag(value string) (tag *mapper.Tag, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			tag, ok = nil, false
+		}
+	}()
+	return mapper.NewTag(value)
+}
+
+// fieldPos returns the position of the field of the struct T, or the element
+// type of T.
+func fieldPos(T types.Type, name string) token.Pos {
+	u := mapper.NewUnderlyingType(T)
+	if u == nil {
+		return token.NoPos
+	}
+	s, ok := u.Underlying().(*types.Struct)
+	if !ok {
+		return token.NoPos
+	}
+	for i := 0; i < s.NumFields(); i++ {
+		if s.Field(i).Name() == name {
+			return s.Field(i).Pos()
+		}
+	}
+	return token.NoPos
+}
+
+// oneLine joins the lines of the pretty errors of the generator.
+func oneLine(err error) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(err.Error(), "\n", "; ")), " ")
+}
@@ -0,0 +1,14 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper/cmd/mapper/analyzer"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer.Analyzer, "a")
+	analysistest.Run(t, testdata, analyzer.Analyzer, "b", "c", "d", "e")
+}
@@ -0,0 +1,28 @@
+package a
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+
+type Mapper interface {
+	ToB(A) B
+
+	//mapper:lenient
+	ToC(A) C
+}
+
+type A struct {
+	ID   int
+	Name string
+}
+
+type B struct {
+	ID       int
+	Name     string
+	Nickname string // want `no mapping found for field "Nickname" in func ToB\(a.A\) a.B`
+	Alias    string `json:"alias"` // want `no mapping found for field "Alias" in func ToB\(a.A\) a.B`
+}
+
+// The fields of C without mapping are left at their zero value.
+type C struct {
+	ID    int
+	Extra string
+}
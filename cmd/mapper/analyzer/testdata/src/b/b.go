@@ -0,0 +1,15 @@
+package b
+
+type User struct {
+	ID      int     `map:",IntToString"`
+	Name    string  `map:",Missing"`           // want `map tag of field "Name" references unknown func: tag "map:\\",Missing\\"" is invalid; detail: func "Missing" not found in "b"`
+	Age     float64 `map:",IntToString"`       // want `map tag of field "Age" references func IntToString\(i int\) string, which neither accepts nor returns float64`
+	Email   string  `map:"Email,Foo.Bar.Baz"`  // want `malformed map tag`
+	Country string  `map:",Formatter.Missing"` // want `map tag of field "Country" references unknown func: .* method "Missing" not found in "Formatter"`
+}
+
+type Formatter struct{}
+
+func IntToString(i int) string {
+	return ""
+}
@@ -0,0 +1,15 @@
+package c
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type=Mapper
+
+type Mapper interface { // want `cannot generate the mapper for interface Mapper: no conversion found for field`
+	ToB(A) B
+}
+
+type A struct {
+	Active int
+}
+
+type B struct {
+	Active struct{}
+}
@@ -0,0 +1,15 @@
+package d
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type=Mapper -converters=d/missing
+
+type Mapper interface { // want `cannot generate the mapper for interface Mapper: cannot load the converters of d/missing`
+	ToB(A) B
+}
+
+type A struct {
+	ID int
+}
+
+type B struct {
+	ID int
+}
@@ -0,0 +1,15 @@
+package e
+
+//mapper:generate
+type Mapper interface {
+	ToB(A) B
+}
+
+type A struct {
+	ID int
+}
+
+type B struct {
+	ID   int
+	Name string // want `no mapping found for field "Name" in func ToB\(e.A\) e.B`
+}
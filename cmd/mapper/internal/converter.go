@@ -0,0 +1,188 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+	"sync"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/loader"
+)
+
+// ConvPkgPath is the package of the bundled converters.
+const ConvPkgPath = "github.com/alextanhongpin/mapper/conv"
+
+var (
+	defaultConverters     *Converters
+	defaultConvertersErr  error
+	defaultConvertersOnce sync.Once
+)
+
+// DefaultConverters returns the converters bundled in the conv package. It
+// panics if the package cannot be loaded.
+func DefaultConverters() *Converters {
+	defaultConvertersOnce.Do(func() {
+		defaultConverters = NewConverters()
+		defaultConvertersErr = defaultConverters.Load(ConvPkgPath)
+	})
+	if defaultConvertersErr != nil {
+		panic(defaultConvertersErr)
+	}
+	return defaultConverters
+}
+
+// ConverterDirective marks a function as a converter, e.g.
+//
+//	//mapper:converter
+//	func CentsToMoney(c Cents) Money
+const ConverterDirective = "converter"
+
+// Converters holds the functions that are used when the LHS and RHS field types
+// differ and no `map` tag is given. The functions are keyed by their param and
+// result type.
+type Converters struct {
+	funcs  map[string]*mapper.Func
+	parent *Converters
+}
+
+func NewConverters() *Converters {
+	return &Converters{
+		funcs: make(map[string]*mapper.Func),
+	}
+}
+
+// Extend returns a new registry, whose converters takes precedence over the
+// converters of the parent.
+func (c *Converters) Extend() *Converters {
+	child := NewConverters()
+	child.parent = c
+	return child
+}
+
+// Load adds the exported functions of the package with one param and one
+// result, and an optional error.
+func (c *Converters) Load(pkgPath string) error {
+	pkg, err := loader.TryLoadPackage(pkgPath)
+	if err != nil {
+		return err
+	}
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		fn, ok := scope.Lookup(name).(*types.Func)
+		if !ok || !fn.Exported() || !isConverter(fn) {
+			continue
+		}
+		if err := c.Add(mapper.NewFunc(fn, nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadAnnotated adds the functions annotated with //mapper:converter in the
+// package.
+func (c *Converters) LoadAnnotated(pkgPath string) error {
+	pkg, err := loader.TryLoadPackage(pkgPath)
+	if err != nil {
+		return err
+	}
+	c.AddAnnotated(pkg.Types, pkg.Syntax)
+	return nil
+}
+
+// AddAnnotated adds the functions annotated with //mapper:converter in the
+// files of the package.
+func (c *Converters) AddAnnotated(pkg *types.Package, files []*ast.File) {
+	directives := mapper.NewDirectives(files)
+
+	var names []string
+	for name := range directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !directives.Has(name, ConverterDirective) {
+			continue
+		}
+
+		fn, ok := pkg.Scope().Lookup(name).(*types.Func)
+		if !ok || !isConverter(fn) {
+			panic(PrettyError(`
+				converter %q is invalid
+				detail: %q is annotated with //mapper:%s
+				hint: converter must be a function with the signature func(A) B or func(A) (B, error)
+			`, name, name, ConverterDirective))
+		}
+		if err := c.Add(mapper.NewFunc(fn, nil)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Add registers the func by its param and result type. Two converters with the
+// same param and result type are ambiguous.
+func (c *Converters) Add(fn *mapper.Func) error {
+	key := converterKey(fn.From.Type, fn.To.Type)
+	if prev, ok := c.funcs[key]; ok {
+		return PrettyError(`
+			converter %q is ambiguous
+			detail: %q and %q both convert %s
+			hint: remove one of the converters, or use the map tag on the field instead
+		`, fn.Name, prev.Fn.FullName(), fn.Fn.FullName(), key)
+	}
+	c.funcs[key] = fn
+	return nil
+}
+
+// Find returns the func that converts lhs to rhs. For slices, the func that
+// converts each element is returned.
+func (c *Converters) Find(lhs, rhs types.Type) (*mapper.Func, bool) {
+	if c == nil {
+		return nil, false
+	}
+	if fn, ok := c.funcs[converterKey(lhs, rhs)]; ok {
+		return fn, true
+	}
+	if mapper.IsSlice(lhs) && mapper.IsSlice(rhs) {
+		if fn, ok := c.funcs[converterKey(elem(lhs), elem(rhs))]; ok {
+			return fn, true
+		}
+	}
+	return c.parent.Find(lhs, rhs)
+}
+
+// Signatures returns the signatures of the converters, including those of
+// the parent, sorted.
+func (c *Converters) Signatures() []string {
+	var result []string
+	for ; c != nil; c = c.parent {
+		for key, fn := range c.funcs {
+			result = append(result, key+" "+types.ObjectString(fn.Fn, nil))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func converterKey(from, to types.Type) string {
+	return types.TypeString(from, nil) + " -> " + types.TypeString(to, nil)
+}
+
+// isConverter returns true if the func has the signature func(A) B or
+// func(A) (B, error).
+func isConverter(fn *types.Func) bool {
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() != nil || sig.Variadic() || sig.Params().Len() != 1 {
+		return false
+	}
+	switch sig.Results().Len() {
+	case 1:
+		return true
+	case 2:
+		return mapper.IsUnderlyingError(sig.Results().At(1).Type())
+	default:
+		return false
+	}
+}
@@ -0,0 +1,263 @@
+package internal
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/alextanhongpin/mapper/loader"
+	. "github.com/dave/jennifer/jen"
+)
+
+// CopyDeep clones the slices, maps and pointers recursively, instead of
+// assigning them.
+const CopyDeep = "deep"
+
+// Copier generates the methods that deep copy the values of the types, so that
+// the target does not share the slices, maps and pointers of the source.
+// Unexported fields of other packages, interfaces, funcs and channels are
+// assigned as it is.
+type Copier struct {
+	pkgPath string
+	types   map[string]types.Type
+}
+
+// NewCopier returns a copier for the code generated in the package pkgPath.
+func NewCopier(pkgPath string) *Copier {
+	return &Copier{
+		pkgPath: pkgPath,
+		types:   make(map[string]types.Type),
+	}
+}
+
+// Copy returns the expression that deep copies the value of type T, e.g.
+// m.copySliceString(a0.Tags). The value is returned as it is if it does not
+// hold any references.
+func (c *Copier) Copy(recv Receiver, value *Statement, T types.Type) *Statement {
+	if !c.hasReferences(T, make(map[types.Type]bool)) {
+		return value
+	}
+
+	name := c.methodName(T)
+	c.types[name] = T
+	return recv.Call(name).Call(value)
+}
+
+// Methods generates the copy methods of the types collected, including the
+// types that are copied by the methods, and returns them with their names,
+// sorted by name.
+func (c *Copier) Methods(recv Receiver) ([]string, []*Statement) {
+	done := make(map[string]*Statement)
+	for len(done) < len(c.types) {
+		for name, T := range c.types {
+			if done[name] != nil {
+				continue
+			}
+			done[name] = c.genMethod(recv, name, T)
+		}
+	}
+
+	names := make([]string, 0, len(done))
+	for name := range done {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]*Statement, len(names))
+	for i, name := range names {
+		result[i] = done[name]
+	}
+	return names, result
+}
+
+func (c *Copier) genMethod(recv Receiver, name string, T types.Type) *Statement {
+	var (
+		in  = Id("in")
+		out = Id("out")
+	)
+
+	return Func().Add(recv.Params()).Id(name).Params(Id("in").Add(GenType(T))).Add(GenType(T)).BlockFunc(func(g *Group) {
+		switch u := T.Underlying().(type) {
+		case *types.Pointer:
+			/*
+				Output:
+
+				if in == nil {
+					return nil
+				}
+				out := m.copyMainAddress(*in)
+				return &out
+			*/
+			g.If(in.Clone().Op("==").Nil()).Block(Return(Nil()))
+			g.Add(out.Clone().Op(":=").Add(c.Copy(recv, Op("*").Add(in.Clone()), u.Elem())))
+			if _, ok := T.(*types.Named); ok {
+				g.Return(Parens(GenType(T)).Call(Op("&").Add(out.Clone())))
+			} else {
+				g.Return(Op("&").Add(out.Clone()))
+			}
+		case *types.Slice:
+			/*
+				Output:
+
+				if in == nil {
+					return nil
+				}
+				out := make([]Address, len(in))
+				for i, v := range in {
+					out[i] = m.copyMainAddress(v)
+				}
+				return out
+			*/
+			g.If(in.Clone().Op("==").Nil()).Block(Return(Nil()))
+			g.Add(out.Clone().Op(":=").Make(GenType(T), Len(in.Clone())))
+			if c.hasReferences(u.Elem(), make(map[types.Type]bool)) {
+				g.For(List(Id("i"), Id("v")).Op(":=").Range().Add(in.Clone())).Block(
+					out.Clone().Index(Id("i")).Op("=").Add(c.Copy(recv, Id("v"), u.Elem())),
+				)
+			} else {
+				g.Copy(out.Clone(), in.Clone())
+			}
+			g.Return(out.Clone())
+		case *types.Map:
+			/*
+				Output:
+
+				if in == nil {
+					return nil
+				}
+				out := make(map[string][]int, len(in))
+				for k, v := range in {
+					out[k] = m.copySliceInt(v)
+				}
+				return out
+			*/
+			g.If(in.Clone().Op("==").Nil()).Block(Return(Nil()))
+			g.Add(out.Clone().Op(":=").Make(GenType(T), Len(in.Clone())))
+			g.For(List(Id("k"), Id("v")).Op(":=").Range().Add(in.Clone())).Block(
+				out.Clone().Index(Id("k")).Op("=").Add(c.Copy(recv, Id("v"), u.Elem())),
+			)
+			g.Return(out.Clone())
+		case *types.Array:
+			/*
+				Output:
+
+				out := in
+				for i, v := range in {
+					out[i] = m.copySliceInt(v)
+				}
+				return out
+			*/
+			g.Add(out.Clone().Op(":=").Add(in.Clone()))
+			g.For(List(Id("i"), Id("v")).Op(":=").Range().Add(in.Clone())).Block(
+				out.Clone().Index(Id("i")).Op("=").Add(c.Copy(recv, Id("v"), u.Elem())),
+			)
+			g.Return(out.Clone())
+		case *types.Struct:
+			/*
+				Output:
+
+				out := in
+				out.Tags = m.copySliceString(in.Tags)
+				return out
+			*/
+			g.Add(out.Clone().Op(":=").Add(in.Clone()))
+			for i := 0; i < u.NumFields(); i++ {
+				field := u.Field(i)
+				if !c.isAccessible(field) || !c.hasReferences(field.Type(), make(map[types.Type]bool)) {
+					continue
+				}
+				g.Add(out.Clone().Dot(field.Name()).Op("=").Add(c.Copy(recv, in.Clone().Dot(field.Name()), field.Type())))
+			}
+			g.Return(out.Clone())
+		default:
+			panic(fmt.Sprintf("mapper: cannot copy %s", T))
+		}
+	}).Line()
+}
+
+// hasReferences returns true if the value of type T holds slices, maps or
+// pointers that can be copied.
+func (c *Copier) hasReferences(T types.Type, seen map[types.Type]bool) bool {
+	if named, ok := T.(*types.Named); ok {
+		if seen[named] {
+			return false
+		}
+		seen[named] = true
+	}
+
+	switch u := T.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	case *types.Array:
+		return c.hasReferences(u.Elem(), seen)
+	case *types.Struct:
+		// Anonymous structs are assigned as it is.
+		if _, ok := T.(*types.Named); !ok {
+			return false
+		}
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			if c.isAccessible(field) && c.hasReferences(field.Type(), seen) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func (c *Copier) isAccessible(field *types.Var) bool {
+	return field.Exported() || field.Pkg() == nil || field.Pkg().Path() == c.pkgPath
+}
+
+// methodName returns the name of the method that copies the type, e.g.
+// copySliceString for []string. The types of other packages are qualified by
+// their import path, since the package names are not unique, e.g.
+// copyGithubComFooModelUser for github.com/foo/model.User.
+func (c *Copier) methodName(T types.Type) string {
+	return "copy" + c.typeName(T)
+}
+
+func (c *Copier) typeName(T types.Type) string {
+	switch u := T.(type) {
+	case *types.Named:
+		var pkg string
+		if p := u.Obj().Pkg(); p != nil {
+			if p.Path() == c.pkgPath {
+				pkg = loader.UpperFirst(p.Name())
+			} else {
+				pkg = pathName(p.Path())
+			}
+		}
+		return pkg + loader.UpperFirst(u.Obj().Name())
+	case *types.Basic:
+		return loader.UpperFirst(u.Name())
+	case *types.Pointer:
+		return "Pointer" + c.typeName(u.Elem())
+	case *types.Slice:
+		return "Slice" + c.typeName(u.Elem())
+	case *types.Array:
+		return fmt.Sprintf("Array%d%s", u.Len(), c.typeName(u.Elem()))
+	case *types.Map:
+		return "Map" + c.typeName(u.Key()) + "To" + c.typeName(u.Elem())
+	case *types.Interface:
+		return "Interface"
+	default:
+		return "Value"
+	}
+}
+
+// pathName returns the import path as an identifier, e.g. GithubComFooModel
+// for github.com/foo/model.
+func pathName(path string) string {
+	parts := strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, part := range parts {
+		parts[i] = loader.UpperFirst(part)
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/alextanhongpin/mapper"
+	. "github.com/dave/jennifer/jen"
+)
+
+// Enums finds the mappings between the enum types, by the name of their
+// constants. The directives are only read from the input package.
+type Enums struct {
+	enums *mapper.Enums
+}
+
+func NewEnums(pkg *types.Package, files []*ast.File) *Enums {
+	return &Enums{
+		enums: mapper.NewEnums(pkg, files),
+	}
+}
+
+// EnumMapping maps the constants of the source enum to the target enum. When
+// no default constant is declared, unknown values returns error.
+type EnumMapping struct {
+	Func    *mapper.Func
+	Cases   []mapper.EnumCase
+	Default *types.Const
+}
+
+// Find returns the mapping from lhs to rhs, if both are enums and at least one
+// of their constants matches. For slices, the mapping of each element is
+// returned.
+func (e *Enums) Find(lhs, rhs types.Type) (*EnumMapping, bool) {
+	if e == nil {
+		return nil, false
+	}
+	if mapper.IsSlice(lhs) && mapper.IsSlice(rhs) {
+		lhs, rhs = elem(lhs), elem(rhs)
+	}
+
+	cases, def := e.enums.Match(lhs, rhs)
+	if len(cases) == 0 {
+		return nil, false
+	}
+
+	fn := mapper.NewFunc(mapper.NormFuncFromTypes("", lhs, rhs), nil)
+	fn.Error = def == nil
+	return &EnumMapping{
+		Func:    fn,
+		Cases:   cases,
+		Default: def,
+	}, true
+}
+
+// GenEnumMethod generates the method that maps the enum with a switch.
+func GenEnumMethod(recv Receiver, em *EnumMapping) *Statement {
+	/*
+		Output:
+
+		func (m *Mapper) mapMainStatusToMainAPIStatus(s0 Status) (APIStatus, error) {
+			switch s0 {
+			case StatusActive:
+				return APIStatusActive, nil
+			default:
+				return 0, fmt.Errorf("mapper: unknown main.Status %v", s0)
+			}
+		}
+	*/
+	fn := em.Func
+	arg := GenInputValue(fn)
+
+	return Func().
+		Add(recv.Params()).
+		Id(fn.NormalizedName()).
+		Params(GenInputType(arg.Clone(), fn)).
+		Add(GenReturnType(fn)).
+		Block(
+			Switch(arg).BlockFunc(func(g *Group) {
+				for _, c := range em.Cases {
+					g.Case(Qual(c.From.Pkg().Path(), c.From.Name())).Block(
+						genEnumReturn(fn, Qual(c.To.Pkg().Path(), c.To.Name())),
+					)
+				}
+				if em.Default != nil {
+					g.Default().Block(
+						Return(Qual(em.Default.Pkg().Path(), em.Default.Name())),
+					)
+				} else {
+					g.Default().Block(
+						Return(
+							zeroValue(fn.To.Type),
+							Qual("fmt", "Errorf").Call(Lit("mapper: unknown "+types.TypeString(fn.From.Type, (*types.Package).Name)+" %v"), arg),
+						),
+					)
+				}
+			}),
+		).Line()
+}
+
+func genEnumReturn(fn *mapper.Func, value *Statement) *Statement {
+	if fn.Error {
+		return Return(value, Nil())
+	}
+	return Return(value)
+}
+
+// zeroValue returns the zero value of the basic type.
+func zeroValue(T types.Type) *Statement {
+	basic, ok := T.Underlying().(*types.Basic)
+	if !ok {
+		return Nil()
+	}
+	switch {
+	case basic.Info()&types.IsString != 0:
+		return Lit("")
+	case basic.Info()&types.IsBoolean != 0:
+		return False()
+	default:
+		return Lit(0)
+	}
+}
@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+// FieldDirective configures the mapping of a target field in the doc comment
+// of the interface method, for structs that cannot be tagged, e.g.
+//
+//	type Mapper interface {
+//		//mapper:field ExternalID <- ID using IntToString
+//		ToExternal(User) external.User
+//	}
+//
+// is the same as tagging ExternalID with `map:"ID,IntToString"`.
+const FieldDirective = "field"
+
+// IgnoreDirective skips the target fields, e.g. //mapper:ignore Password.
+const IgnoreDirective = "ignore"
+
+// LenientDirective leaves the target fields without mapping at their zero
+// value, instead of failing, for the target types that cannot be tagged, e.g.
+// the responses of third party APIs. It applies to every method when the
+// interface is annotated, or to the annotated method only.
+const LenientDirective = "lenient"
+
+// IsLenient returns true if the method or the interface name is annotated with
+// the lenient directive. The method is keyed by the interface and method
+// name, e.g. Mapper.ToB.
+func IsLenient(directives mapper.Directives, name, key string) bool {
+	return directives.Has(key, LenientDirective) || (name != "" && directives.Has(name, LenientDirective))
+}
+
+// NewMethodTags returns the tags of the target fields configured by the
+// directives of an interface method, keyed by the field name. Funcs without
+// package path are loaded from the package pkgPath, where the interface is
+// declared.
+func NewMethodTags(directives []mapper.Directive, pkgPath string) (map[string]*mapper.Tag, error) {
+	result := make(map[string]*mapper.Tag)
+	for _, d := range directives {
+		switch d.Name {
+		case IgnoreDirective:
+			if len(d.Args) == 0 {
+				return nil, fmt.Errorf("%q is missing the field name", d)
+			}
+			for _, name := range d.Args {
+				result[name] = &mapper.Tag{Ignore: true}
+			}
+		case FieldDirective:
+			name, tag, err := parseFieldDirective(d)
+			if err != nil {
+				return nil, err
+			}
+			if tag.HasFunc() && !tag.IsImported() {
+				tag.PkgPath = pkgPath
+			}
+			// The loaded funcs are keyed by the tag, which must not collide with
+			// the struct tags.
+			tag.Tag = d.String()
+			result[name] = tag
+		}
+	}
+	return result, nil
+}
+
+// parseFieldDirective parses the args of the field directive, which are one
+// of the following:
+//
+//	Target <- Source
+//	Target using Func
+//	Target <- Source using Func
+func parseFieldDirective(d mapper.Directive) (string, *mapper.Tag, error) {
+	args := d.Args
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("%q is missing the field name", d)
+	}
+	var src, fn string
+	name, args := args[0], args[1:]
+	if len(args) >= 2 && args[0] == "<-" {
+		src, args = args[1], args[2:]
+	}
+	if len(args) >= 2 && args[0] == "using" {
+		fn, args = args[1], args[2:]
+	}
+	if len(args) > 0 || (src == "" && fn == "") {
+		return "", nil, fmt.Errorf("%q does not match %q", d, "//mapper:field Target <- Source using Func")
+	}
+
+	value := src
+	if fn != "" {
+		value = src + "," + fn
+	}
+	tag, ok := mapper.NewTag(fmt.Sprintf(`map:%q`, value))
+	if !ok {
+		return "", nil, fmt.Errorf("%q has invalid source %q or func %q", d, src, fn)
+	}
+	return name, tag, nil
+}
@@ -65,5 +65,5 @@ func (f FieldResolver) IsMethod() bool {
 }
 
 func (f FieldResolver) Tag() *mapper.Tag {
-	return f.rhs.Tag
+	return mapper.MergeTag(f.lhs.Tag, f.rhs.Tag)
 }
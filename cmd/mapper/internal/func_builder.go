@@ -38,20 +38,29 @@ func (b *FuncBuilder) BuildFuncCall(fn *mapper.Func, lhs, rhs types.Type) *State
 	})
 }
 
-func (b *FuncBuilder) BuildMethodCall(prefix *Statement, fn *mapper.Func, lhs, rhs types.Type) *Statement {
+// BuildMethodCall builds the call to the method. The args are passed after the
+// value, e.g. the visited references of the private mappers.
+func (b *FuncBuilder) BuildMethodCall(prefix *Statement, fn *mapper.Func, lhs, rhs types.Type, args ...Code) *Statement {
 	return b.buildFunc(fn, lhs, rhs, func(assign *Statement, op string) *Statement {
-		return b.genMethodCall(prefix, assign, op, fn, lhs, rhs)
+		return b.genMethodCall(prefix, assign, op, fn, lhs, rhs, args...)
 	})
 }
 
-func (b *FuncBuilder) genMethodCall(prefix, assign *Statement, op string, method *mapper.Func, lhs, rhs types.Type) *Statement {
+func (b *FuncBuilder) genMethodCall(prefix, assign *Statement, op string, method *mapper.Func, lhs, rhs types.Type, args ...Code) *Statement {
+	// When mapping slice to slice, the func is applied to each element.
+	each := mapper.IsSlice(lhs) && mapper.IsSlice(rhs) && !mapper.IsSlice(method.From.Type)
+	in := lhs
+	if each {
+		in = elem(lhs)
+	}
+
 	var (
 		r                    = b.resolver
 		a0Selection          = r.RhsVar
-		requiresInputPointer = method.RequiresInputPointer(lhs)
-		requiresInputValue   = method.RequiresInputValue(lhs)
+		requiresInputPointer = method.RequiresInputPointer(in)
+		requiresInputValue   = method.RequiresInputValue(in)
 	)
-	fnCall := prefix.Clone().Call(Do(func(s *Statement) {
+	value := Do(func(s *Statement) {
 		if requiresInputPointer {
 			// Output:
 			// fn.Fn(&a0Name)
@@ -62,12 +71,13 @@ func (b *FuncBuilder) genMethodCall(prefix, assign *Statement, op string, method
 			s.Add(Op("*"))
 		}
 
-		if mapper.IsSlice(lhs) && mapper.IsSlice(rhs) && !mapper.IsSlice(method.From.Type) {
+		if each {
 			s.Add(Id("each"))
 		} else {
 			s.Add(a0Selection())
 		}
-	}))
+	})
+	fnCall := prefix.Clone().Call(append([]Code{value}, args...)...)
 
 	if !method.Error {
 		return assign.Clone().Op(op).Add(fnCall)
@@ -200,126 +210,78 @@ func slice2slice(r Resolver, fn *mapper.Func, lhs, rhs types.Type, fnAssignment
 		a0Selection = r.RhsVar
 	)
 
-	inp := mapper.IsPointer(lhs)
+	// The pointers are checked against the slice elements, e.g. []*A.
+	inp := mapper.IsPointer(elem(lhs))
 	argp := mapper.IsPointer(fn.From.Type)
-	outp := mapper.IsPointer(rhs)
+	outp := mapper.IsPointer(elem(rhs))
 	resp := mapper.IsPointer(fn.To.Type)
 
-	if inp != argp && inp {
-		if outp != resp && resp {
-			/*
-				Output:
-
-				var a0Name []b.B
-				for _, each := range a0.Name {
-					if each != nil {
-						tmp := fn.Fn(*each)
-						if tmp != nil {
-							a0Name = append(a0Name, *tmp) // Expects value return.
-							a0Name = append(a0Name, tmp) 	// Expects pointer return.
-						}
-					}
-				}
-			*/return NewMulti(
-				Var().Add(a0Name()).Add(GenType(rhs)),
-				For(List(Id("_"), Id("each")).Op(":=").Range().Add(a0Selection())).Block(
-					If(Id("each").Op("!=").Nil().Block(
-						fnAssignment(Id("tmp"), "="),
-						If(Id("tmp").Op("!=").Nil()).Block(
-							a0Selection().Op("=").Append(a0Selection(), Do(func(s *Statement) {
-								if outp != resp {
-									if outp {
-										s.Add(Op("&"))
-									} else {
-										s.Add(Op("*"))
-									}
-								}
-							}).Id("tmp")),
-						),
-					)),
-				)).Statement()
-
-		}
-
+	if inp == argp && outp == resp {
 		/*
+			Condition: in/out matches the arg/res.
 			Output:
 
-			var a0Name []b.B
-			for _, each := range a0.Name {
-				if each != nil {
-					tmp := fn.Fn(*each)
-					a0Name = append(a0Name, *tmp) // Expects value return.
-					a0Name = append(a0Name, tmp) 	// Expects pointer return.
-				}
+			a0Name := make([]b.B, len(a0.Name))
+			for i, each := range a0.Name {
+				a0Name[i] = pkgfn.Fn(each)
 			}
-		*/return NewMulti(
-			Var().Add(a0Name()).Add(GenType(rhs)),
-			For(List(Id("_"), Id("each")).Op(":=").Range().Add(a0Selection())).Block(
-				If(Id("each").Op("!=").Nil().Block(
-					fnAssignment(Id("tmp"), "="),
-					a0Selection().Op("=").Append(a0Selection(), Do(func(s *Statement) {
-						if outp == resp {
-							return
-						}
-
-						if outp {
-							s.Add(Op("&"))
-						} else {
-							s.Add(Op("*"))
-						}
-					}).Id("tmp")),
-				)),
-			)).Statement()
-
-	}
-
-	if resp {
-		/*
-			Condition: in is not pointer, out is pointer.
-			Output:
-
-			var a0Name [](*)b.B
-			for _, each := range a0.Name {
-				tmp := pkgfn.Fn(each)
-				if tmp != nil {
-					a0Name = append(a0Name, &tmp) // Expects output pointer for value result.
-					a0Name = append(a0Name, tmp)  // Expects output pointer for pointer result.
-					a0Name = append(a0Name, *tmp) // Expects output value for pointer result.
-				}
-			}
-		*/return NewMulti(
-			Var().Add(a0Name()).Add(GenType(rhs)),
-			For(List(Id("_"), Id("each")).Op(":=").Range().Add(a0Selection())).Block(
-				fnAssignment(Id("tmp"), "="),
-				If(Id("tmp").Op("!=").Nil()).Block(
-					a0Name().Op("=").Append(a0Name(), Do(func(s *Statement) {
-						if resp == outp {
-							return
-						}
-
-						if outp {
-							s.Add(Op("&"))
-						} else {
-							s.Add(Op("*"))
-						}
-					}).Id("tmp")),
-				),
+		*/
+		return NewMulti(
+			a0Name().Op(":=").Make(Add(GenType(rhs)), Len(a0Selection())),
+			For(List(Id("i"), Id("each")).Op(":=").Range().Add(a0Selection())).Block(
+				fnAssignment(a0Name().Index(Id("i")), "="),
 			)).Statement()
-
 	}
 
 	/*
-		Condition: in/out is not pointer.
 		Output:
 
-		a0Name := make([]b.B, a0.Name)
-		for i, each := range a0.Name {
-			a0Name[i] = pkgfn.Fn(each)
+		a0Name := make([](*)b.B, 0, len(a0.Name))
+		for _, each := range a0.Name {
+			if each == nil {
+				continue // Only when the input pointer is dereferenced.
+			}
+			tmp := fn.Fn(*each)
+			if tmp == nil {
+				continue // Only when the result pointer is dereferenced.
+			}
+			a0Name = append(a0Name, tmp)  // Expects the same result.
+			a0Name = append(a0Name, &tmp) // Expects output pointer for value result.
+			a0Name = append(a0Name, *tmp) // Expects output value for pointer result.
 		}
-	*/return NewMulti(
-		a0Name().Op(":=").Make(Add(GenType(rhs)), Len(a0Selection())),
-		For(List(Id("i"), Id("each")).Op(":=").Range().Add(a0Selection())).Block(
-			fnAssignment(a0Name().Index(Id("i")), "="),
-		)).Statement()
+	*/
+	return NewMulti(
+		a0Name().Op(":=").Make(Add(GenType(rhs)), Lit(0), Len(a0Selection())),
+		For(List(Id("_"), Id("each")).Op(":=").Range().Add(a0Selection())).BlockFunc(func(g *Group) {
+			if inp && !argp {
+				g.If(Id("each").Op("==").Nil()).Block(Continue())
+			}
+			g.Add(fnAssignment(Id("tmp"), ":="))
+			if resp && !outp {
+				g.If(Id("tmp").Op("==").Nil()).Block(Continue())
+			}
+			g.Add(a0Name().Op("=").Append(a0Name(), Do(func(s *Statement) {
+				if outp == resp {
+					return
+				}
 
+				if outp {
+					s.Add(Op("&"))
+				} else {
+					s.Add(Op("*"))
+				}
+			}).Id("tmp")))
+		})).Statement()
+}
+
+// elem returns the element type of a slice or array.
+func elem(T types.Type) types.Type {
+	switch u := T.(type) {
+	case *types.Slice:
+		return u.Elem()
+	case *types.Array:
+		return u.Elem()
+	default:
+		return T
+	}
 }
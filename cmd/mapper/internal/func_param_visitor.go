@@ -1,19 +1,28 @@
 package internal
 
 import (
+	"fmt"
 	"go/types"
 
 	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/loader"
 )
 
 type FuncParamVisitor struct {
+	pkgs         *Packages
+	named        *types.Named
 	fields       mapper.StructFields
 	methods      map[string]*mapper.Func
+	mappersByTag map[string]*mapper.Func
 	isCollection bool
+	isProto      bool
 }
 
-func NewFuncParamVisitor() *FuncParamVisitor {
-	return &FuncParamVisitor{}
+func NewFuncParamVisitor(pkgs *Packages) *FuncParamVisitor {
+	return &FuncParamVisitor{
+		pkgs:         pkgs,
+		mappersByTag: make(map[string]*mapper.Func),
+	}
 }
 
 func (v *FuncParamVisitor) Visit(T types.Type) bool {
@@ -21,14 +30,61 @@ func (v *FuncParamVisitor) Visit(T types.Type) bool {
 	case *types.Array, *types.Slice:
 		v.isCollection = true
 	case *types.Named:
+		v.named = u
 		v.methods = mapper.NewNamedVisitor(u).Methods()
 	case *types.Struct:
-		v.fields = mapper.NewStructFields(u).WithTags()
+		// Source fields are keyed by the name they are exposed as, so that
+		// `map:"PublicName"` on the source renames the outgoing field.
+		fields, err := mapper.NewStructFields(u).Outgoing()
+		if err != nil {
+			var T types.Type = u
+			if v.named != nil {
+				T = v.named
+			}
+			panic(fmt.Errorf("invalid source struct %s\ndetail: %v\nhelp: rename the alias in the map tag of one of the fields, or ignore it with `map:\"-\"`", types.TypeString(T, (*types.Package).Name), err))
+		}
+		v.fields = fields
+		v.isProto = mapper.IsProtoMessage(u)
 		return false
 	}
 	return true
 }
 
+// loadMapper resolves the func of the tag of the source field, which
+// transforms the field before it is mapped. It is only called when the tag
+// applies, see mapper.MergeTag, since the same struct may be the target of
+// another method, where the tag converts to the field type instead.
+func (v *FuncParamVisitor) loadMapper(field mapper.StructField) {
+	tag := field.Tag
+	if _, ok := v.mappersByTag[tag.Tag]; ok {
+		return
+	}
+
+	var m *mapper.Func
+	if tag.IsFunc() {
+		m = v.pkgs.loadFunc(field)
+	}
+	if tag.IsMethod() {
+		m = v.pkgs.loadMethod(field)
+	}
+
+	// The source function transforms the field before it is mapped, so it
+	// must accept the source field type.
+	if !mapper.IsUnderlyingIdentical(m.From.Type, field.Type) {
+		panic(PrettyError(`
+			tag %q is invalid
+			detail: %q does not accept field %q of type %s
+		`, tag.Tag, m.Name, field.Name, field.Type))
+	}
+	v.mappersByTag[tag.Tag] = m
+}
+
+// StructFields returns the source fields, keyed by the name they are exposed
+// as.
+func (v FuncParamVisitor) StructFields() mapper.StructFields {
+	return v.fields
+}
+
 func (v FuncParamVisitor) FieldByName(name string) (mapper.StructField, bool) {
 	field, ok := v.fields[name]
 	return field, ok
@@ -39,6 +95,34 @@ func (v FuncParamVisitor) MethodByName(name string) (*mapper.Func, bool) {
 	return method, ok
 }
 
+// IsProto returns true if the LHS is a protobuf message, whose fields are read
+// through the nil-safe getters.
+func (v FuncParamVisitor) IsProto() bool {
+	return v.isProto
+}
+
+// GetterByField returns the exported getter of an unexported field, e.g.
+// Name() or GetName() for the field name, and ID() or GetID() for id.
+func (v FuncParamVisitor) GetterByField(field mapper.StructField) (*mapper.Func, bool) {
+	for _, name := range []string{
+		loader.UpperFirst(field.Name),
+		loader.UpperCommonInitialism(field.Name),
+		"Get" + loader.UpperFirst(field.Name),
+		"Get" + loader.UpperCommonInitialism(field.Name),
+	} {
+		method, ok := v.methods[name]
+		if ok && mapper.IsIdentical(method.To.Type, field.Type) {
+			return method, true
+		}
+	}
+	return nil, false
+}
+
+func (v FuncParamVisitor) MapperByTag(tag string) (*mapper.Func, bool) {
+	mapper, ok := v.mappersByTag[tag]
+	return mapper, ok
+}
+
 // HasError returns true if the LHS field is method call
 // and returns error as the result tuple.
 func (v FuncParamVisitor) HasError() bool {
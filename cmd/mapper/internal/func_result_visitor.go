@@ -1,19 +1,22 @@
 package internal
 
 import (
+	"fmt"
 	"go/types"
 
 	"github.com/alextanhongpin/mapper"
 )
 
 type FuncResultVisitor struct {
+	pkgs         *Packages
 	fields       mapper.StructFields
 	mappersByTag map[string]*mapper.Func
 	isCollection bool
 }
 
-func NewFuncResultVisitor() *FuncResultVisitor {
+func NewFuncResultVisitor(pkgs *Packages) *FuncResultVisitor {
 	return &FuncResultVisitor{
+		pkgs:         pkgs,
 		mappersByTag: make(map[string]*mapper.Func),
 	}
 }
@@ -26,58 +29,82 @@ func (v *FuncResultVisitor) Visit(T types.Type) bool {
 	case *types.Struct:
 		v.fields = mapper.NewStructFields(u).WithTags()
 		for _, field := range v.fields {
-			tag := field.Tag
-			if tag == nil {
-				continue
-			}
-			if !tag.HasFunc() {
-				continue
-			}
-			var m *mapper.Func
-			if tag.IsFunc() {
-				fn := loadFunc(field)
-				v.mappersByTag[tag.Tag] = fn
-				// Avoid overwriting if the struct loads multiple
-				// functions and some does not have errors.
-				m = fn
-			}
+			v.loadMapper(field)
+		}
+	}
+	return true
+}
 
-			if tag.IsMethod() {
-				met := loadMethod(field)
-				v.mappersByTag[tag.Tag] = met
-				m = met
-			}
+// Override replaces the tags of the target fields, e.g. with the tags
+// configured by the directives of the interface method. Fields with the ignore
+// tag are removed.
+func (v *FuncResultVisitor) Override(tags map[string]*mapper.Tag) error {
+	for name, tag := range tags {
+		field, ok := v.fields[name]
+		if !ok {
+			return fmt.Errorf("field %q not found", name)
+		}
+		if tag.Ignore {
+			delete(v.fields, name)
+			continue
+		}
+		field.Tag = tag
+		v.fields[name] = field
+		v.loadMapper(field)
+	}
+	return nil
+}
 
-			/*
-				Return underlying type should match.
-				If the field type is []A, *A or just A, then the function/method should
-				also return the equivalent type A.
-
-				type Foo struct {
-					// AddSalutation accepts string, so it will be mapped over the names.
-					names []string `map:",AddSalutation"`
-
-					// CheckAge returns an error as the second return value.
-					age int64 `map:",CheckAge"`
-				}
-
-				func Rename(name string) string {
-					return "Mr/Ms " + name
-				}
-
-				func CheckAge(age int64) (int64, error) {
-					if age < 0 || age > 150 {
-						return 0, errors.New("invalid age")
-					}
-					return age, nil
-				}
-			*/
-			if !mapper.IsUnderlyingIdentical(m.To.Type, field.Type) {
-				panic("not equal type")
+func (v *FuncResultVisitor) loadMapper(field mapper.StructField) {
+	tag := field.Tag
+	if tag == nil {
+		return
+	}
+	if !tag.HasFunc() {
+		return
+	}
+	var m *mapper.Func
+	if tag.IsFunc() {
+		fn := v.pkgs.loadFunc(field)
+		v.mappersByTag[tag.Tag] = fn
+		// Avoid overwriting if the struct loads multiple
+		// functions and some does not have errors.
+		m = fn
+	}
+
+	if tag.IsMethod() {
+		met := v.pkgs.loadMethod(field)
+		v.mappersByTag[tag.Tag] = met
+		m = met
+	}
+
+	/*
+		Return underlying type should match.
+		If the field type is []A, *A or just A, then the function/method should
+		also return the equivalent type A.
+
+		type Foo struct {
+			// AddSalutation accepts string, so it will be mapped over the names.
+			names []string `map:",AddSalutation"`
+
+			// CheckAge returns an error as the second return value.
+			age int64 `map:",CheckAge"`
+		}
+
+		func Rename(name string) string {
+			return "Mr/Ms " + name
+		}
+
+		func CheckAge(age int64) (int64, error) {
+			if age < 0 || age > 150 {
+				return 0, errors.New("invalid age")
 			}
+			return age, nil
 		}
+	*/
+	if !mapper.IsUnderlyingIdentical(m.To.Type, field.Type) {
+		panic("not equal type")
 	}
-	return true
 }
 
 func (v *FuncResultVisitor) HasError() bool {
@@ -97,6 +124,11 @@ func (v FuncResultVisitor) Fields() []string {
 	return result
 }
 
+// StructFields returns the target fields, keyed by the field name.
+func (v FuncResultVisitor) StructFields() mapper.StructFields {
+	return v.fields
+}
+
 func (v FuncResultVisitor) FieldByName(name string) (mapper.StructField, bool) {
 	field, ok := v.fields[name]
 	return field, ok
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"go/types"
+	"sort"
 
 	"github.com/alextanhongpin/mapper"
 )
@@ -9,6 +10,16 @@ import (
 type FuncVisitor struct {
 	Param  *FuncParamVisitor
 	Result *FuncResultVisitor
+	pkgs   *Packages
+
+	// Set when the fields are converted by converters that returns error.
+	hasConverterError bool
+}
+
+// NewFuncVisitor returns the visitor of the mapper funcs, which resolves the
+// funcs of the tags from the packages.
+func NewFuncVisitor(pkgs *Packages) *FuncVisitor {
+	return &FuncVisitor{pkgs: pkgs}
 }
 
 func (f *FuncVisitor) Visit(fn *types.Func) {
@@ -87,11 +98,11 @@ func (f *FuncVisitor) Visit(fn *types.Func) {
 	}
 
 	param := sig.Params().At(0).Type()
-	paramVisitor := NewFuncParamVisitor()
+	paramVisitor := NewFuncParamVisitor(f.pkgs)
 	_ = mapper.Walk(paramVisitor, param)
 
 	result := sig.Results().At(0).Type()
-	resultVisitor := NewFuncResultVisitor()
+	resultVisitor := NewFuncResultVisitor(f.pkgs)
 	_ = mapper.Walk(resultVisitor, result)
 
 	/*
@@ -135,5 +146,61 @@ func (f *FuncVisitor) Visit(fn *types.Func) {
 }
 
 func (f FuncVisitor) HasError() bool {
-	return f.Result.HasError() || f.Param.HasError()
+	return f.Result.HasError() || f.Param.HasError() || f.hasConverterError
+}
+
+// MapperByTag returns the function loaded by the tag, which may be declared on
+// either the target or the source struct field.
+func (f FuncVisitor) MapperByTag(tag string) (*mapper.Func, bool) {
+	if fn, ok := f.Result.MapperByTag(tag); ok {
+		return fn, true
+	}
+	return f.Param.MapperByTag(tag)
+}
+
+// Tag returns the tag that applies to the target field, taking into account
+// the tag of the source field it is mapped from. See mapper.MergeTag for the
+// precedence rules.
+func (f FuncVisitor) Tag(rhs mapper.StructField) *mapper.Tag {
+	key := rhs.Name
+	if rhs.Tag != nil && rhs.Tag.IsAlias() {
+		key = rhs.Tag.Name
+	}
+
+	lhs, ok := f.Param.FieldByName(key)
+	if !ok {
+		return rhs.Tag
+	}
+	tag := mapper.MergeTag(lhs.Tag, rhs.Tag)
+	if tag != rhs.Tag && tag.HasFunc() {
+		// The func of the source tag applies.
+		f.Param.loadMapper(lhs)
+	}
+	return tag
+}
+
+// HasMapping returns true if the target field is mapped from a source field or
+// method with the same name or alias.
+func (f FuncVisitor) HasMapping(rhs mapper.StructField) bool {
+	key := rhs.Name
+	if rhs.Tag != nil && rhs.Tag.IsAlias() {
+		key = rhs.Tag.Name
+	}
+	_, hasField := f.Param.FieldByName(key)
+	_, hasMethod := f.Param.MethodByName(key)
+	return hasField || hasMethod
+}
+
+// UnmappedFields returns the names of the target fields without mapping,
+// sorted by name.
+func (f FuncVisitor) UnmappedFields() []string {
+	var result []string
+	for _, name := range f.Result.Fields() {
+		rhs, _ := f.Result.FieldByName(name)
+		if !f.HasMapping(rhs) {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
 }
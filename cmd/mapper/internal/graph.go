@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The formats of the dependency graph.
+const (
+	GraphDOT  = "dot"
+	GraphJSON = "json"
+)
+
+// The kinds of the nodes of the dependency graph.
+const (
+	NodeMethod     = "method"     // The interface method.
+	NodeMapper     = "mapper"     // The private mapper.
+	NodeFunc       = "func"       // The func of the map tag, or the converter.
+	NodeEnum       = "enum"       // The enum mapper.
+	NodeDependency = "dependency" // The struct or interface injected for the tag methods.
+)
+
+// GraphNode is a mapper, func or dependency.
+type GraphNode struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+}
+
+// GraphEdge is the call from a mapper to another mapper, func or dependency.
+// The kind is the kind of the transformation, e.g. tag.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// Graph is the dependency graph of the generated mappers.
+type Graph struct {
+	Interface string      `json:"interface"`
+	Nodes     []GraphNode `json:"nodes"`
+	Edges     []GraphEdge `json:"edges"`
+	// The private mappers that call each other, e.g. for recursive types.
+	Cycles [][]string `json:"cycles"`
+	// The interface methods whose private mapper is not called by the other
+	// mappers. They are unused, unless called by the application.
+	Unused []string `json:"unused"`
+
+	nodes map[string]string
+	edges map[GraphEdge]bool
+}
+
+func NewGraph(name string) *Graph {
+	return &Graph{
+		Interface: name,
+		nodes:     make(map[string]string),
+		edges:     make(map[GraphEdge]bool),
+	}
+}
+
+// AddMethod adds the interface method, which calls the private mapper.
+func (g *Graph) AddMethod(method, mapperName string) {
+	g.nodes[method] = NodeMethod
+	g.nodes[mapperName] = NodeMapper
+	g.edges[GraphEdge{From: method, To: mapperName, Kind: NodeMethod}] = true
+}
+
+// AddEdge adds the call from the private mapper with the transformation of
+// the kind, e.g. SourceTag.
+func (g *Graph) AddEdge(from, to, kind string) {
+	switch kind {
+	case SourceMapper:
+		g.nodes[from], g.nodes[to] = NodeMapper, NodeMapper
+	case SourceEnum:
+		g.nodes[from], g.nodes[to] = NodeMapper, NodeEnum
+	case SourceTag, SourceConverter:
+		g.nodes[from], g.nodes[to] = NodeMapper, NodeFunc
+	case NodeDependency:
+		// The tag method is the method of the dependency.
+		g.nodes[from], g.nodes[to] = NodeFunc, NodeDependency
+	default:
+		// The inline transformations, e.g. proto, are not calls.
+		return
+	}
+	g.edges[GraphEdge{From: from, To: to, Kind: kind}] = true
+}
+
+// Build sorts the nodes and edges, and finds the cycles and unused methods.
+func (g *Graph) Build() *Graph {
+	g.Nodes = []GraphNode{}
+	for id, kind := range g.nodes {
+		g.Nodes = append(g.Nodes, GraphNode{ID: id, Kind: kind})
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+
+	g.Edges = []GraphEdge{}
+	for e := range g.edges {
+		g.Edges = append(g.Edges, e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	g.Cycles = g.cycles()
+
+	// The private mappers called by the other mappers.
+	called := make(map[string]bool)
+	for _, e := range g.Edges {
+		if e.Kind == SourceMapper && e.From != e.To {
+			called[e.To] = true
+		}
+	}
+	g.Unused = []string{}
+	for _, e := range g.Edges {
+		if e.Kind == NodeMethod && !called[e.To] {
+			g.Unused = append(g.Unused, e.From)
+		}
+	}
+	return g
+}
+
+// cycles returns the strongly connected mappers, with Tarjan's algorithm.
+func (g *Graph) cycles() [][]string {
+	adj := make(map[string][]string)
+	selfLoop := make(map[string]bool)
+	for _, e := range g.Edges {
+		if e.Kind != SourceMapper {
+			continue
+		}
+		adj[e.From] = append(adj[e.From], e.To)
+		if e.From == e.To {
+			selfLoop[e.From] = true
+		}
+	}
+
+	var (
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		result  = [][]string{}
+		visit   func(string)
+	)
+	visit = func(v string) {
+		index[v] = len(index)
+		lowlink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adj[v] {
+			if _, ok := index[w]; !ok {
+				visit(w)
+				if lowlink[w] < lowlink[v] {
+					lowlink[v] = lowlink[w]
+				}
+			} else if onStack[w] && index[w] < lowlink[v] {
+				lowlink[v] = index[w]
+			}
+		}
+
+		if lowlink[v] != index[v] {
+			return
+		}
+		var scc []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		if len(scc) > 1 || selfLoop[v] {
+			sort.Strings(scc)
+			result = append(result, scc)
+		}
+	}
+
+	for _, n := range g.Nodes {
+		if _, ok := index[n.ID]; !ok && n.Kind == NodeMapper {
+			visit(n.ID)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i][0] < result[j][0]
+	})
+	return result
+}
+
+// Render renders the graph in the format, dot or json.
+func (g *Graph) Render(format string) ([]byte, error) {
+	switch format {
+	case GraphJSON:
+		b, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	case GraphDOT:
+		return g.dot(), nil
+	default:
+		return nil, fmt.Errorf("invalid graph format %q, must be dot or json", format)
+	}
+}
+
+var nodeShapes = map[string]string{
+	NodeMethod:     "box",
+	NodeMapper:     "ellipse",
+	NodeFunc:       "note",
+	NodeEnum:       "diamond",
+	NodeDependency: "component",
+}
+
+func (g *Graph) dot() []byte {
+	/*
+		Output:
+
+		digraph Mapper {
+			"ToB" [shape=box];
+			"mapMainAToMainB" [shape=ellipse];
+			"ToB" -> "mapMainAToMainB";
+			"mapMainAToMainB" -> "IntToString" [label=tag];
+		}
+	*/
+	cycleOf := make(map[string]int)
+	for i, cycle := range g.Cycles {
+		for _, id := range cycle {
+			cycleOf[id] = i + 1
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "digraph %q {\n", g.Interface)
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "\t%q [shape=%s];\n", n.ID, nodeShapes[n.Kind])
+	}
+	for _, e := range g.Edges {
+		var attrs []string
+		if e.Kind != NodeMethod {
+			attrs = append(attrs, "label="+e.Kind)
+		}
+		// The calls between the mappers of the same cycle are highlighted.
+		if e.Kind == SourceMapper && cycleOf[e.From] > 0 && cycleOf[e.From] == cycleOf[e.To] {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(&b, "\t%q -> %q", e.From, e.To)
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/types"
+	"hash"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+// InputHashPrefix precedes the input hash in the header of the generated
+// file, e.g.
+//
+//	// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+//	// Input hash: sha256:2c26b46b68ffc68f...
+const InputHashPrefix = "Input hash: "
+
+// Version of the generator, which is part of the input hash. Bump it when the
+// generated code changes, so that the files generated by the previous version
+// are regenerated.
+const Version = "v0.2.0"
+
+// InputHash hashes the inputs of a generated file, which are the interfaces,
+// the types they reach with their tags, methods and constants, and the
+// signatures of the funcs they reference. The generated file is unchanged if
+// the hash is unchanged.
+type InputHash struct {
+	h    hash.Hash
+	pkgs *Packages
+	seen map[types.Type]bool
+}
+
+// NewInputHash returns the hash, which resolves the funcs referenced by the
+// tags from the packages.
+func NewInputHash(pkgs *Packages) *InputHash {
+	h := &InputHash{
+		h:    sha256.New(),
+		pkgs: pkgs,
+		seen: make(map[types.Type]bool),
+	}
+	h.Write("version %s", Version)
+	return h
+}
+
+// Write adds a line to the hash.
+func (h *InputHash) Write(format string, args ...interface{}) {
+	fmt.Fprintf(h.h, format+"\n", args...)
+}
+
+// AddType adds the type, and the types it reaches through the fields,
+// elements, signatures and methods.
+func (h *InputHash) AddType(T types.Type) {
+	if T == nil || h.seen[T] {
+		return
+	}
+	h.seen[T] = true
+
+	switch t := T.(type) {
+	case *types.Named:
+		h.addNamed(t)
+	case *types.Pointer:
+		h.AddType(t.Elem())
+	case *types.Slice:
+		h.AddType(t.Elem())
+	case *types.Array:
+		h.AddType(t.Elem())
+	case *types.Map:
+		h.AddType(t.Key())
+		h.AddType(t.Elem())
+	case *types.Chan:
+		h.AddType(t.Elem())
+	case *types.Signature:
+		for i := 0; i < t.Params().Len(); i++ {
+			h.AddType(t.Params().At(i).Type())
+		}
+		for i := 0; i < t.Results().Len(); i++ {
+			h.AddType(t.Results().At(i).Type())
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			h.AddType(t.Method(i).Type())
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			field := t.Field(i)
+			h.AddType(field.Type())
+			h.addTagFunc(field, reflect.StructTag(t.Tag(i)))
+		}
+	}
+}
+
+// addNamed adds the declaration of the named type, with its methods and
+// constants, e.g. the enums and the implementations of the sum types.
+func (h *InputHash) addNamed(t *types.Named) {
+	h.Write("type %s %s", types.TypeString(t, nil), types.TypeString(t.Underlying(), nil))
+	for i := 0; i < t.NumMethods(); i++ {
+		fn := t.Method(i)
+		h.Write("func %s", fn.FullName()+strings.TrimPrefix(types.TypeString(fn.Type(), nil), "func"))
+		h.AddType(fn.Type())
+	}
+
+	if pkg := t.Obj().Pkg(); pkg != nil {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			c, ok := scope.Lookup(name).(*types.Const)
+			if ok && types.Identical(c.Type(), t) {
+				h.Write("const %s %s = %s", c.Name(), types.TypeString(t, nil), c.Val())
+			}
+		}
+	}
+	for _, variant := range mapper.SumVariants(t) {
+		h.AddType(variant.Type)
+	}
+	h.AddType(t.Underlying())
+}
+
+// addTagFunc adds the func of the map tag of the field.
+func (h *InputHash) addTagFunc(field *types.Var, tag reflect.StructTag) {
+	if field.Pkg() == nil {
+		return
+	}
+	t, ok := mapper.NewTag(string(tag))
+	if !ok || !t.HasFunc() {
+		return
+	}
+	h.AddTagFunc(t, field.Pkg().Path())
+}
+
+// AddTagFunc adds the signature of the func or method referenced by the tag,
+// e.g. the tags of the fields and the //mapper:field directives. Funcs
+// without package path are resolved in the package pkgPath.
+func (h *InputHash) AddTagFunc(tag *mapper.Tag, pkgPath string) {
+	fn, err := h.pkgs.LoadTagFunc(mapper.StructField{
+		Tag:     tag,
+		PkgPath: pkgPath,
+	})
+	if err != nil {
+		// The generation reports the error.
+		h.Write("tag %s %s: %v", pkgPath, tag.Tag, err)
+		return
+	}
+	if fn.Fn == nil {
+		h.Write("tag %s %s: %s", pkgPath, tag.Tag, fn.Name)
+		return
+	}
+	h.Write("tag %s %s: %s", pkgPath, tag.Tag, types.ObjectString(fn.Fn, nil))
+	h.AddType(fn.Fn.Type())
+}
+
+// Sum returns the hash, e.g. sha256:2c26b46b68ffc68f...
+func (h *InputHash) Sum() string {
+	return "sha256:" + hex.EncodeToString(h.h.Sum(nil))
+}
+
+// ReadInputHash returns the input hash in the header of the generated file.
+func ReadInputHash(path string) (string, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	prefix := "// " + InputHashPrefix
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimPrefix(line, prefix), true
+		}
+	}
+	return "", false
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"fmt"
 	"go/types"
+	"sort"
+	"strings"
 
 	"github.com/alextanhongpin/mapper"
 )
@@ -12,6 +14,14 @@ type InterfaceVisitor struct {
 	methodInfo       map[string]*FuncVisitor
 	mappers          map[string]bool
 	hasErrorByMapper map[string]bool
+	pkgs             *Packages
+	converters       *Converters
+	enums            *Enums
+	name             string
+	directives       mapper.Directives
+	configured       map[string]bool
+	unmapped         map[string][]string
+	nested           map[string]*mapper.Func // The methods that map the nested fields, keyed by signature.
 }
 
 func (v *InterfaceVisitor) Visit(T types.Type) bool {
@@ -24,46 +34,63 @@ func (v *InterfaceVisitor) Visit(T types.Type) bool {
 	return true
 }
 
-func NewInterfaceVisitor(T types.Type) *InterfaceVisitor {
+// NewInterfaceVisitor parses the methods of the interface T with the given
+// name. The directives in the doc comments of the methods configure the
+// mapping of the method, and the funcs of the tags are resolved from pkgs.
+func NewInterfaceVisitor(name string, T types.Type, pkgs *Packages, converters *Converters, enums *Enums, directives mapper.Directives) *InterfaceVisitor {
 	v := &InterfaceVisitor{
+		name:             name,
+		directives:       directives,
+		configured:       make(map[string]bool),
+		unmapped:         make(map[string][]string),
+		nested:           make(map[string]*mapper.Func),
 		mappers:          make(map[string]bool),
 		hasErrorByMapper: make(map[string]bool),
 		methodInfo:       make(map[string]*FuncVisitor),
+		pkgs:             pkgs,
+		converters:       converters,
+		enums:            enums,
 	}
 	_ = mapper.Walk(v, T.Underlying())
 	return v
 }
 
 func (v *InterfaceVisitor) parseMethods() {
+	var names []string
+	for name := range v.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
 	for name, fn := range v.methods {
-		fv := &FuncVisitor{}
+		fv := NewFuncVisitor(v.pkgs)
 		fv.Visit(fn.Fn)
+		v.configure(fn, fv)
 
 		// Store the func info.
 		v.methodInfo[name] = fv
 		signature := fn.Normalize().Signature()
+
+		// Configured methods have their own private mapper, which cannot be
+		// reused by the other methods.
+		if v.configured[name] {
+			signature = name
+		}
 		v.hasErrorByMapper[signature] = fv.HasError()
 
-		result, param := fv.Result, fv.Param
+		result := fv.Result
 
 		// checkFieldsHasMappings
 		for _, name := range result.Fields() {
 			rhs, _ := result.FieldByName(name)
-			key := name
-			if rhs.Tag != nil && rhs.Tag.IsAlias() {
-				key = rhs.Tag.Name
-			}
-			_, hasField := param.FieldByName(key)
-			_, hasMethod := param.MethodByName(key)
-
-			if !(hasField || hasMethod) {
+			if !fv.HasMapping(rhs) {
 				panic(fmt.Errorf("no mapping found for %q", name))
 			}
 
 			// There's a custom mapper.
-			if rhs.Tag != nil && rhs.Tag.HasFunc() {
+			if tag := fv.Tag(rhs); tag != nil && tag.HasFunc() {
 				//mapperFn(lhs) rhs
-				mapperFn, _ := result.MapperByTag(rhs.Tag.Tag)
+				mapperFn, _ := fv.MapperByTag(tag.Tag)
 				if mapperFn.Error {
 					// If the parent does not have error, but the inner function does, it
 					// is invalid.
@@ -79,13 +106,30 @@ func (v *InterfaceVisitor) parseMethods() {
 		v.mappers[signature] = true
 	}
 
-	for name := range v.methods {
-		res := v.methodInfo[name]
+	// The nested fields are mapped by the method with the same signature. The
+	// methods without directives are preferred, since the directives change
+	// the mapping of the pair, e.g. a lenient method.
+	for _, name := range names {
+		fn := v.methods[name]
+		signature := fn.Normalize().Signature()
+		if prev, ok := v.nested[signature]; ok && (!v.configured[prev.Name] || v.configured[fn.Name]) {
+			continue
+		}
+		v.nested[signature] = fn
+	}
+
+	for methodName, fn := range v.methods {
+		res := v.methodInfo[methodName]
+		signature := fn.Normalize().Signature()
+		if v.configured[methodName] {
+			signature = methodName
+		}
 
 		result, param := res.Result, res.Param
 
 		for _, name := range result.Fields() {
 			rhs, _ := result.FieldByName(name)
+			tag := res.Tag(rhs)
 			if rhs.Tag != nil && rhs.Tag.IsAlias() {
 				name = rhs.Tag.Name
 			}
@@ -102,7 +146,7 @@ func (v *InterfaceVisitor) parseMethods() {
 			rhsType := rhs.Type
 
 			// There's a custom mapper.
-			if rhs.Tag != nil && rhs.Tag.HasFunc() {
+			if tag != nil && tag.HasFunc() {
 				/*
 					func CustomFunc(param Param) (Result) {
 					}
@@ -118,7 +162,7 @@ func (v *InterfaceVisitor) parseMethods() {
 					CustomFunc(LHS.param) == RHS.result
 
 				*/
-				mapperFn, _ := result.MapperByTag(rhs.Tag.Tag)
+				mapperFn, _ := res.MapperByTag(tag.Tag)
 				paramType := mapperFn.From.Type
 				resultType := mapperFn.To.Type
 
@@ -134,9 +178,59 @@ func (v *InterfaceVisitor) parseMethods() {
 				continue
 			}
 
+			// Dereferencing the pointer requires a converter, e.g. *time.Time
+			// to time.Time, although the underlying types are identical.
+			if mapper.IsPointer(lhsType) && !mapper.IsPointer(rhsType) && mapper.IsUnderlyingIdentical(lhsType, rhsType) {
+				if conv, ok := v.converters.Find(lhsType, rhsType); ok {
+					v.checkConverter(fn, res, signature, conv, rhs)
+					continue
+				}
+			}
+
 			if !mapper.IsUnderlyingIdentical(lhsType, rhsType) {
+				// Protobuf well-known types are converted inline.
+				if _, ok := NewProtoConversion(lhsType, rhsType); ok {
+					continue
+				}
+
+				// Interfaces, including protobuf oneof, are mapped to the
+				// implementations of the target interface.
+				if mapper.IsSumType(lhsType) && types.IsInterface(rhsType) {
+					v.checkSumType(rhs, lhsType, rhsType)
+					// The variants declared outside the package of the interface
+					// are unhandled, which fails the mapper if it returns error.
+					if fn.Error {
+						res.hasConverterError = true
+						v.hasErrorByMapper[signature] = true
+					}
+					continue
+				}
+
+				// Use the converter for the types, since no tag is given.
+				if conv, ok := v.converters.Find(lhsType, rhsType); ok {
+					v.checkConverter(fn, res, signature, conv, rhs)
+					continue
+				}
+
+				// Enums are mapped by the name of their constants.
+				if enum, ok := v.enums.Find(lhsType, rhsType); ok {
+					if enum.Func.Error {
+						if !fn.Error {
+							enumName := types.TypeString(enum.Func.To.Type, (*types.Package).Name)
+							panic(PrettyError(`
+								function %q is missing error return
+								detail: enum %s has no default for unknown values of field %q
+								hint: add error return, or annotate a constant of %s with //mapper:enum default
+							`, PrettyFuncSignature(fn.Fn), enumName, rhs.Name, enumName))
+						}
+						res.hasConverterError = true
+						v.hasErrorByMapper[signature] = true
+					}
+					continue
+				}
+
 				innerSignature := mapper.NewFunc(mapper.NormFuncFromTypes("", lhsType, rhsType), nil).Signature()
-				if !v.mappers[innerSignature] {
+				if _, ok := v.NestedMethod(innerSignature); !ok {
 					panic("no conversion found for field")
 				}
 			}
@@ -144,6 +238,117 @@ func (v *InterfaceVisitor) parseMethods() {
 	}
 }
 
+// configure applies the field and ignore directives of the method to the
+// target fields. In lenient mode, the target fields without mapping are
+// ignored.
+func (v *InterfaceVisitor) configure(fn *mapper.Func, fv *FuncVisitor) {
+	// The //mapper:func vars are keyed by the var name.
+	key := fn.Name
+	if v.name != "" {
+		key = v.name + "." + fn.Name
+	}
+	directives := append(v.directives.Lookup(key, FieldDirective), v.directives.Lookup(key, IgnoreDirective)...)
+	if len(directives) > 0 {
+		tags, err := NewMethodTags(directives, fn.PkgPath)
+		if err == nil {
+			err = fv.Result.Override(tags)
+		}
+		if err != nil {
+			panic(PrettyError(`
+				method %q has invalid directive
+				detail: %s
+				hint: use //mapper:field Target <- Source using Func, or //mapper:ignore Target
+			`, PrettyFuncSignature(fn.Fn), err))
+		}
+		v.configured[fn.Name] = true
+	}
+
+	if !IsLenient(v.directives, v.name, key) {
+		return
+	}
+	unmapped := fv.UnmappedFields()
+	if len(unmapped) == 0 {
+		return
+	}
+	tags := make(map[string]*mapper.Tag)
+	for _, name := range unmapped {
+		tags[name] = &mapper.Tag{Ignore: true}
+	}
+	if err := fv.Result.Override(tags); err != nil {
+		panic(err)
+	}
+	v.unmapped[fn.Name] = unmapped
+	v.configured[fn.Name] = true
+}
+
+// PrivateMethod returns the private mapper of the interface method. Methods
+// configured with directives have their own private mapper named after the
+// method, e.g. mapToExternalUser, since the mapping differs from the other
+// methods with the same signature.
+func (v *InterfaceVisitor) PrivateMethod(fn *mapper.Func) *mapper.Func {
+	if !v.configured[fn.Name] {
+		return fn.Normalize()
+	}
+	return mapper.NewFunc(mapper.NormFunc("map"+fn.Name, fn.Fn), fn.Obj)
+}
+
+// NestedMethod returns the interface method that maps the nested fields with
+// the signature, e.g. func(User) UserDTO. The method is called by its private
+// mapper, see PrivateMethod.
+func (v *InterfaceVisitor) NestedMethod(signature string) (*mapper.Func, bool) {
+	fn, ok := v.nested[signature]
+	return fn, ok
+}
+
+// IsConfigured returns true if the method is configured with directives.
+func (v *InterfaceVisitor) IsConfigured(name string) bool {
+	return v.configured[name]
+}
+
+// Unmapped returns the target fields of the method that are left at their
+// zero value in lenient mode, sorted by name.
+func (v *InterfaceVisitor) Unmapped(name string) []string {
+	return v.unmapped[name]
+}
+
+// checkConverter marks the mapper as returning error if the converter of the
+// field returns error, which requires the method to return error.
+func (v *InterfaceVisitor) checkConverter(fn *mapper.Func, res *FuncVisitor, signature string, conv *mapper.Func, rhs mapper.StructField) {
+	if !conv.Error {
+		return
+	}
+	if !fn.Error {
+		panic(PrettyError(`
+			function %q is missing error return
+			detail: converter %q for field %q returns error
+			hint: add error return
+		`, PrettyFuncSignature(fn.Fn), PrettyFuncSignature(conv.Fn), rhs.Name))
+	}
+	res.hasConverterError = true
+	v.hasErrorByMapper[signature] = true
+}
+
+// checkSumType checks that every variant of the interface can be mapped to the
+// target interface.
+func (v *InterfaceVisitor) checkSumType(rhs mapper.StructField, lhsType, rhsType types.Type) {
+	var missing []string
+	for _, variant := range mapper.SumVariants(lhsType) {
+		if _, _, ok := FindSumMapper(v.methods, variant, rhsType); !ok {
+			missing = append(missing, fmt.Sprintf("func(%s) %s",
+				types.TypeString(variant.Value(), (*types.Package).Name),
+				types.TypeString(rhsType, (*types.Package).Name),
+			))
+		}
+	}
+	if len(missing) > 0 {
+		panic(PrettyError(`
+			no conversion found for the variants of field %q
+			help: add the following method(s) to the interface:
+			%s
+		`, rhs.Name, strings.Join(missing, "\n")))
+	}
+}
+
 func (v *InterfaceVisitor) Methods() map[string]*mapper.Func {
 	return v.methods
 }
@@ -8,7 +8,68 @@ import (
 	"github.com/alextanhongpin/mapper/loader"
 )
 
-func loadFunc(field mapper.StructField) *mapper.Func {
+// Packages resolves the packages of the funcs and methods referenced by the
+// tags. The type checked packages, e.g. the input package and its imports, are
+// used as is, and the others are loaded once. Each run of the generator, or
+// pass of the analyzer, has its own packages, since the types of different
+// loads are not identical.
+type Packages struct {
+	pkgs map[string]*types.Package
+}
+
+// NewPackages returns the packages, with the given packages and their imports.
+func NewPackages(pkgs ...*types.Package) *Packages {
+	p := &Packages{pkgs: make(map[string]*types.Package)}
+	for _, pkg := range pkgs {
+		p.add(pkg)
+	}
+	return p
+}
+
+func (p *Packages) add(pkg *types.Package) {
+	if pkg == nil || p.pkgs[pkg.Path()] != nil {
+		return
+	}
+	p.pkgs[pkg.Path()] = pkg
+	for _, imp := range pkg.Imports() {
+		p.add(imp)
+	}
+}
+
+// load returns the package, or panics with the errors of the package, which
+// are recovered by LoadTagFunc.
+func (p *Packages) load(pkgPath string) *types.Package {
+	if pkg, ok := p.pkgs[pkgPath]; ok {
+		return pkg
+	}
+	pkg, err := loader.TryLoadPackage(pkgPath)
+	if err != nil {
+		panic(err)
+	}
+	p.pkgs[pkgPath] = pkg.Types
+	return pkg.Types
+}
+
+// LoadTagFunc returns the func or method referenced by the tag of the field,
+// or error if it cannot be resolved.
+func (p *Packages) LoadTagFunc(field mapper.StructField) (fn *mapper.Func, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	if field.Tag.IsMethod() {
+		return p.loadMethod(field), nil
+	}
+	return p.loadFunc(field), nil
+}
+
+func (p *Packages) loadFunc(field mapper.StructField) *mapper.Func {
 	tag := field.Tag
 	// Use the field pkg path from where the left function
 	// reside. It may be on different files.
@@ -18,10 +79,10 @@ func loadFunc(field mapper.StructField) *mapper.Func {
 	}
 
 	// Load the function.
-	pkg := loader.LoadPackage(fieldPkgPath)
-	obj := pkg.Types.Scope().Lookup(tag.Func)
+	pkg := p.load(fieldPkgPath)
+	obj := pkg.Scope().Lookup(tag.Func)
 	if obj == nil {
-		panic("func not found")
+		panic(fmt.Errorf("tag %q is invalid\ndetail: func %q not found in %q", tag.Tag, tag.Func, fieldPkgPath))
 	}
 
 	T, ok := obj.(*types.Func)
@@ -32,7 +93,7 @@ func loadFunc(field mapper.StructField) *mapper.Func {
 	return mapper.NewFunc(T, nil)
 }
 
-func loadMethod(field mapper.StructField) *mapper.Func {
+func (p *Packages) loadMethod(field mapper.StructField) *mapper.Func {
 	tag := field.Tag
 	fieldPkgPath := field.PkgPath
 	if tag.IsImported() {
@@ -40,8 +101,8 @@ func loadMethod(field mapper.StructField) *mapper.Func {
 	}
 
 	// Load the interface/struct.
-	pkg := loader.LoadPackage(fieldPkgPath)
-	obj := pkg.Types.Scope().Lookup(tag.TypeName)
+	pkg := p.load(fieldPkgPath)
+	obj := pkg.Scope().Lookup(tag.TypeName)
 	if obj == nil {
 		panic(fmt.Errorf("tag %q is invalid\ndetail: %q not found\nhelp: check if the type %q exists", tag.Tag, tag.TypeName, tag.TypeName))
 	}
@@ -54,13 +115,19 @@ func loadMethod(field mapper.StructField) *mapper.Func {
 	T := obj.Type()
 	if types.IsInterface(T) {
 		interfaceMethods := mapper.NewInterfaceMethods(T)
-		fn := interfaceMethods[tag.Func]
+		fn, ok := interfaceMethods[tag.Func]
+		if !ok {
+			panic(fmt.Errorf("tag %q is invalid\ndetail: method %q not found in %q", tag.Tag, tag.Func, tag.TypeName))
+		}
 		fn.Obj = named.Obj()
 		return fn
 	}
 
 	structMethods := mapper.NewNamedVisitor(T).Methods()
-	method := structMethods[tag.Func]
+	method, ok := structMethods[tag.Func]
+	if !ok {
+		panic(fmt.Errorf("tag %q is invalid\ndetail: method %q not found in %q", tag.Tag, tag.Func, tag.TypeName))
+	}
 	method.Obj = named.Obj()
 	return method
 }
@@ -14,11 +14,10 @@ type MethodResolver struct {
 }
 
 func NewMethodResolver(name string, lhs *mapper.Func, rhs mapper.StructField) *MethodResolver {
+	// The assigned variables are named after the RHS field, since the method
+	// may be a getter, e.g. GetName().
 	fieldName0 := lhs.Name
-	fieldNameN := lhs.Name
-	if rhs.Tag != nil && rhs.Tag.IsAlias() {
-		fieldNameN = rhs.Name
-	}
+	fieldNameN := rhs.Name
 	return &MethodResolver{
 		lhs:    lhs,
 		rhs:    rhs,
@@ -26,6 +26,11 @@ func (v *OutputTypeVisitor) Visit(T types.Type) bool {
 		v.code = v.code.Nil()
 		return false
 	case *types.Named:
+		// Named basic types, e.g. enums, have no composite literal.
+		if _, ok := u.Underlying().(*types.Basic); ok {
+			v.code = v.code.Add(zeroValue(u))
+			return false
+		}
 		o := u.Obj()
 		p := o.Pkg()
 		v.code = v.code.Qual(p.Path(), o.Name()).Values()
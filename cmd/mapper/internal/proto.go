@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"go/types"
+
+	"github.com/alextanhongpin/mapper"
+	. "github.com/dave/jennifer/jen"
+)
+
+type protoWrapperType struct {
+	Type string // The wrapped type, e.g. string
+	New  string // The constructor in wrapperspb, e.g. String
+}
+
+// protoWrappers maps the protobuf wrapper types to the Go type they wrap.
+var protoWrappers = map[string]protoWrapperType{
+	"BoolValue":   {"bool", "Bool"},
+	"BytesValue":  {"[]byte", "Bytes"},
+	"DoubleValue": {"float64", "Double"},
+	"FloatValue":  {"float32", "Float"},
+	"Int32Value":  {"int32", "Int32"},
+	"Int64Value":  {"int64", "Int64"},
+	"StringValue": {"string", "String"},
+	"UInt32Value": {"uint32", "UInt32"},
+	"UInt64Value": {"uint64", "UInt64"},
+}
+
+// ProtoConversion builds the conversion between a protobuf well-known type
+// and its Go equivalent.
+type ProtoConversion func(r Resolver) *Statement
+
+// NewProtoConversion returns the conversion from lhs to rhs, if one of them is
+// a protobuf well-known type:
+//
+//	*timestamppb.Timestamp <-> time.Time
+//	*durationpb.Duration   <-> time.Duration
+//	*wrapperspb.StringValue <-> *string
+func NewProtoConversion(lhs, rhs types.Type) (ProtoConversion, bool) {
+	switch {
+	case isProtoWellKnown(lhs, "timestamppb", "Timestamp") && isNamed(rhs, "time", "Time"):
+		/*
+			Output:
+
+			var a0Name time.Time
+			if v := a0.GetName(); v != nil {
+				a0Name = v.AsTime()
+			}
+		*/
+		return func(r Resolver) *Statement {
+			return NewMulti(
+				Var().Add(r.LhsVar()).Add(GenType(rhs)),
+				If(Id("v").Op(":=").Add(r.RhsVar()), Id("v").Op("!=").Nil()).Block(
+					r.LhsVar().Op("=").Id("v").Dot("AsTime").Call(),
+				),
+			).Statement()
+		}, true
+	case isNamed(lhs, "time", "Time") && isProtoWellKnown(rhs, "timestamppb", "Timestamp"):
+		/*
+			Output:
+
+			var a0Name *timestamppb.Timestamp
+			if !a0.Name.IsZero() {
+				a0Name = timestamppb.New(a0.Name)
+			}
+		*/
+		return func(r Resolver) *Statement {
+			return NewMulti(
+				Var().Add(r.LhsVar()).Add(GenType(rhs)),
+				If(Op("!").Add(r.RhsVar()).Dot("IsZero").Call()).Block(
+					r.LhsVar().Op("=").Add(protoQual(rhs, "New")).Call(r.RhsVar()),
+				),
+			).Statement()
+		}, true
+	case isProtoWellKnown(lhs, "durationpb", "Duration") && isNamed(rhs, "time", "Duration"):
+		/*
+			Output:
+
+			a0Name := a0.GetName().AsDuration()
+		*/
+		return func(r Resolver) *Statement {
+			return r.LhsVar().Op(":=").Add(r.RhsVar()).Dot("AsDuration").Call()
+		}, true
+	case isNamed(lhs, "time", "Duration") && isProtoWellKnown(rhs, "durationpb", "Duration"):
+		/*
+			Output:
+
+			a0Name := durationpb.New(a0.Name)
+		*/
+		return func(r Resolver) *Statement {
+			return r.LhsVar().Op(":=").Add(protoQual(rhs, "New")).Call(r.RhsVar())
+		}, true
+	}
+
+	if wrapper, ok := protoWrapper(lhs); ok && isPointerTo(rhs, wrapper.Type) {
+		/*
+			Output:
+
+			var a0Name *string
+			if v := a0.GetName(); v != nil {
+				tmp := v.GetValue()
+				a0Name = &tmp
+			}
+		*/
+		return func(r Resolver) *Statement {
+			return NewMulti(
+				Var().Add(r.LhsVar()).Add(GenType(rhs)),
+				If(Id("v").Op(":=").Add(r.RhsVar()), Id("v").Op("!=").Nil()).Block(
+					Id("tmp").Op(":=").Id("v").Dot("GetValue").Call(),
+					r.LhsVar().Op("=").Op("&").Id("tmp"),
+				),
+			).Statement()
+		}, true
+	}
+
+	if wrapper, ok := protoWrapper(rhs); ok && isPointerTo(lhs, wrapper.Type) {
+		/*
+			Output:
+
+			var a0Name *wrapperspb.StringValue
+			if a0.Name != nil {
+				a0Name = wrapperspb.String(*a0.Name)
+			}
+		*/
+		return func(r Resolver) *Statement {
+			return NewMulti(
+				Var().Add(r.LhsVar()).Add(GenType(rhs)),
+				If(r.RhsVar().Op("!=").Nil()).Block(
+					r.LhsVar().Op("=").Add(protoQual(rhs, wrapper.New)).Call(Op("*").Add(r.RhsVar())),
+				),
+			).Statement()
+		}, true
+	}
+
+	return nil, false
+}
+
+func isNamed(T types.Type, pkg, name string) bool {
+	named, ok := T.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Name() == pkg && named.Obj().Name() == name
+}
+
+// isProtoWellKnown returns true if the type is a pointer to the well-known
+// type. The package is matched by name, since the well-known types are
+// identified by their package, e.g. google.golang.org/protobuf/types/known/timestamppb.
+func isProtoWellKnown(T types.Type, pkg, name string) bool {
+	ptr, ok := T.(*types.Pointer)
+	return ok && isNamed(ptr.Elem(), pkg, name)
+}
+
+func isPointerTo(T types.Type, elem string) bool {
+	ptr, ok := T.(*types.Pointer)
+	return ok && ptr.Elem().String() == elem
+}
+
+func protoWrapper(T types.Type) (protoWrapperType, bool) {
+	ptr, ok := T.(*types.Pointer)
+	if !ok {
+		return protoWrapperType{}, false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Name() != "wrapperspb" {
+		return protoWrapperType{}, false
+	}
+	wrapper, ok := protoWrappers[named.Obj().Name()]
+	return wrapper, ok
+}
+
+// protoQual returns the qualified func in the package of the well-known type.
+func protoQual(T types.Type, fn string) *Statement {
+	obj := mapper.NewUnderlyingVisitor(T).Obj()
+	return Qual(obj.Pkg().Path(), fn)
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	. "github.com/dave/jennifer/jen"
+)
+
+// Receiver is the receiver of the generated mappers, e.g. (m *MapperImpl). In
+// func mode, the mappers are generated as plain functions without receiver.
+type Receiver struct {
+	Name string // e.g. m
+	Type string // e.g. MapperImpl
+	Func bool
+}
+
+// Params generates the receiver of the method declaration, or nothing for
+// plain functions.
+func (r Receiver) Params() *Statement {
+	// Output:
+	//
+	// (m *MapperImpl)
+	if r.Func {
+		return Null()
+	}
+	return Params(Id(r.Name).Op("*").Id(r.Type))
+}
+
+// Call generates the reference to the mapper with the given name, e.g.
+// m.mapMainAToMainB, or mapMainAToMainB for plain functions.
+func (r Receiver) Call(name string) *Statement {
+	if r.Func {
+		return Id(name)
+	}
+	return Id(r.Name).Dot(name)
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+	. "github.com/dave/jennifer/jen"
+)
+
+// Visited is the map of the source pointers to the target pointers that are
+// already mapped, which is passed to the private mappers to preserve shared
+// references and terminate cycles. The targets are keyed by the pair of the
+// source pointer and the target type, since the same source may be mapped to
+// different targets, e.g. *User to *UserDTO and *UserSummary.
+const Visited = "visited"
+
+// GenVisitedParam generates the param of the visited references.
+func GenVisitedParam() *Statement {
+	// Output:
+	//
+	// visited map[interface{}]interface{}
+	return Id(Visited).Map(Interface()).Interface()
+}
+
+// GenVisitedVar generates the visited references of a public method.
+func GenVisitedVar() *Statement {
+	// Output:
+	//
+	// visited := make(map[interface{}]interface{})
+	return Id(Visited).Op(":=").Make(Map(Interface()).Interface())
+}
+
+// RefMapper maps the pointer of the source to the pointer of the target with
+// the private mapper, e.g. *Node to *NodeDTO.
+type RefMapper struct {
+	Func   *mapper.Func // The ref mapper, e.g. mapRefMainNodeToMainNodeDTO
+	Method *mapper.Func // The private mapper, e.g. mapMainNodeToMainNodeDTO
+}
+
+// IsRef returns true if both lhs and rhs, or their slice elements, are
+// pointers.
+func IsRef(lhs, rhs types.Type) bool {
+	if mapper.IsSlice(lhs) && mapper.IsSlice(rhs) {
+		lhs, rhs = elem(lhs), elem(rhs)
+	}
+	return mapper.IsPointer(lhs) && mapper.IsPointer(rhs)
+}
+
+// NewRefMapper returns the mapper from the pointer of the param to the pointer
+// of the result of the private mapper, e.g. mapRefMainNodeToMainNodeDTO for
+// mapMainNodeToMainNodeDTO. Private mappers that already accepts pointer, e.g.
+// protobuf messages, are returned as it is.
+func NewRefMapper(method *mapper.Func) (*RefMapper, bool) {
+	if mapper.IsPointer(method.From.Type) || mapper.IsPointer(method.To.Type) {
+		return nil, false
+	}
+
+	name := strings.Replace(method.Name, "map", "mapRef", 1)
+	params := types.NewTuple(types.NewVar(token.NoPos, nil, "", types.NewPointer(method.From.Type)))
+	results := types.NewTuple(types.NewVar(token.NoPos, nil, "", types.NewPointer(method.To.Type)))
+	fn := mapper.NewFunc(types.NewFunc(token.NoPos, nil, name, types.NewSignature(nil, params, results, false)), nil)
+	fn.Error = method.Error
+	return &RefMapper{Func: fn, Method: method}, true
+}
+
+// GenRefMethod generates the method that returns the target that is already
+// mapped for the source pointer, or maps the source with the private mapper.
+// The target is visited before mapping the fields, so that the cycles back to
+// the source are terminated.
+func GenRefMethod(recv Receiver, ref *RefMapper) *Statement {
+	/*
+		Output:
+
+		func (m *Mapper) mapRefMainNodeToMainNodeDTO(n0 *Node, visited map[interface{}]interface{}) *NodeDTO {
+			if n0 == nil {
+				return nil
+			}
+			key := [2]interface{}{n0, (*NodeDTO)(nil)}
+			if n1, ok := visited[key]; ok {
+				return n1.(*NodeDTO)
+			}
+			n1 := new(NodeDTO)
+			visited[key] = n1
+			*n1 = m.mapMainNodeToMainNodeDTO(*n0, visited)
+			return n1
+		}
+	*/
+	var (
+		fn     = ref.Func
+		method = ref.Method
+		in     = GenInputValue(fn)
+		out    = Id(argsWithIndex(fn.From.Name, 1))
+		ret    = func(value Code) *Statement {
+			if fn.Error {
+				return Return(value, Nil())
+			}
+			return Return(value)
+		}
+		call = recv.Call(method.Name).Call(Op("*").Add(in.Clone()), Id(Visited))
+	)
+
+	return Func().
+		Add(recv.Params()).
+		Id(fn.Name).
+		Params(GenInputType(in.Clone(), fn), GenVisitedParam()).
+		Add(GenReturnType(fn)).
+		BlockFunc(func(g *Group) {
+			g.If(in.Clone().Op("==").Nil()).Block(ret(Nil()))
+			g.Id("key").Op(":=").Index(Lit(2)).Interface().Values(in.Clone(), Parens(GenType(fn.To.Type)).Call(Nil()))
+			g.If(List(out.Clone(), Id("ok")).Op(":=").Id(Visited).Index(Id("key")), Id("ok")).Block(
+				ret(out.Clone().Assert(GenType(fn.To.Type))),
+			)
+			g.Add(out.Clone().Op(":=").New(GenType(method.To.Type)))
+			g.Id(Visited).Index(Id("key")).Op("=").Add(out.Clone())
+			if fn.Error {
+				g.List(Id("tmp"), Err()).Op(":=").Add(call)
+				g.If(Err().Op("!=").Nil()).Block(Return(Nil(), Err()))
+				g.Op("*").Add(out.Clone()).Op("=").Id("tmp")
+			} else {
+				g.Op("*").Add(out.Clone()).Op("=").Add(call)
+			}
+			g.Add(ret(out.Clone()))
+		}).Line()
+}
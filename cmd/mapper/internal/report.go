@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+// The formats of the mapping report.
+const (
+	ReportMarkdown = "md"
+	ReportJSON     = "json"
+)
+
+// The kinds of the source of the target fields.
+const (
+	SourceField     = "field"     // Assigned from the source field.
+	SourceMethod    = "method"    // Assigned from the source method.
+	SourceTag       = "tag"       // Transformed by the func or method of the map tag.
+	SourceMapper    = "mapper"    // Mapped by another private mapper.
+	SourceConverter = "converter" // Converted by a converter, without tag.
+	SourceEnum      = "enum"      // Mapped by the names of the enum constants.
+	SourceProto     = "proto"     // Converted from the protobuf well-known type.
+	SourceSum       = "sum"       // Mapped by the variants of the interface.
+	SourceIgnored   = "ignored"   // Not mapped.
+)
+
+// Report lists the source of every target field of the interface methods.
+type Report struct {
+	Interface string          `json:"interface"`
+	Methods   []*MethodReport `json:"methods"`
+}
+
+// MethodReport lists the target fields of the method, and the source fields
+// that are never read.
+type MethodReport struct {
+	Name   string         `json:"name"`   // e.g. ToB
+	From   string         `json:"from"`   // e.g. main.A
+	To     string         `json:"to"`     // e.g. main.B
+	Mapper string         `json:"mapper"` // The private mapper, e.g. mapMainAToMainB
+	Fields []*FieldReport `json:"fields"`
+	Unread []string       `json:"unread"`
+}
+
+// FieldReport is the source of the target field, and the transformation chain
+// from the source to the target field.
+type FieldReport struct {
+	Name   string   `json:"name"`
+	Source string   `json:"source,omitempty"` // e.g. Name, or Name() for methods
+	Kind   string   `json:"kind"`
+	Chain  []string `json:"chain"` // e.g. strconv.Itoa, mapMainAToMainB
+}
+
+// NewFieldReport returns the report of the target field, read from the source
+// field or method.
+func NewFieldReport(name, source, kind string) *FieldReport {
+	return &FieldReport{
+		Name:   name,
+		Source: source,
+		Kind:   kind,
+		Chain:  []string{},
+	}
+}
+
+// Add appends the transformation to the chain. The kind of the field is the
+// first transformation.
+func (f *FieldReport) Add(kind, step string) {
+	if f.Kind == SourceField || f.Kind == SourceMethod {
+		f.Kind = kind
+	}
+	f.Chain = append(f.Chain, step)
+}
+
+// NewMethodReport returns the report of the method, with the target fields
+// sorted by name, and the unread source fields.
+func NewMethodReport(fn *mapper.Func, mapperName string, fields []*FieldReport, sources []string) *MethodReport {
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+
+	read := make(map[string]bool)
+	for _, f := range fields {
+		read[strings.TrimSuffix(f.Source, "()")] = true
+	}
+	unread := []string{}
+	for _, src := range sources {
+		if !read[src] {
+			unread = append(unread, src)
+		}
+	}
+	sort.Strings(unread)
+
+	return &MethodReport{
+		Name:   fn.Name,
+		From:   types.TypeString(fn.From.Type, (*types.Package).Name),
+		To:     types.TypeString(fn.To.Type, (*types.Package).Name),
+		Mapper: mapperName,
+		Fields: fields,
+		Unread: unread,
+	}
+}
+
+// Render renders the report in the format, md or json.
+func (r *Report) Render(format string) ([]byte, error) {
+	switch format {
+	case ReportJSON:
+		b, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	case ReportMarkdown:
+		var b bytes.Buffer
+		r.markdown(&b)
+		return b.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("invalid report format %q, must be md or json", format)
+	}
+}
+
+func (r *Report) markdown(b *bytes.Buffer) {
+	/*
+		Output:
+
+		# Mapper
+
+		## ToB
+
+		`main.A` to `main.B`, mapped by `mapMainAToMainB`.
+
+		| Field | Source | Kind | Chain |
+		| --- | --- | --- | --- |
+		| ID | `ID` | tag | `IntToString` |
+		| Password | | ignored | |
+
+		Unread source fields: `Secret`
+	*/
+	fmt.Fprintf(b, "# %s\n", r.Interface)
+	for _, m := range r.Methods {
+		fmt.Fprintf(b, "\n## %s\n\n", m.Name)
+		fmt.Fprintf(b, "%s to %s, mapped by %s.\n\n", code(m.From), code(m.To), code(m.Mapper))
+		b.WriteString("| Field | Source | Kind | Chain |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, f := range m.Fields {
+			fmt.Fprintf(b, "| %s | %s | %s | %s |\n", f.Name, code(f.Source), f.Kind, code(strings.Join(f.Chain, " → ")))
+		}
+		if len(m.Unread) > 0 {
+			unread := make([]string, len(m.Unread))
+			for i, name := range m.Unread {
+				unread[i] = code(name)
+			}
+			fmt.Fprintf(b, "\nUnread source fields: %s\n", strings.Join(unread, ", "))
+		}
+	}
+}
+
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + s + "`"
+}
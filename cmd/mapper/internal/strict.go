@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+// StrictDirective requires the methods of the interface to read every
+// exported source field, e.g.
+//
+//	//mapper:strict
+//	type Mapper interface {
+//		//mapper:drop Password
+//		ToDTO(User) UserDTO
+//	}
+//
+// The source fields that are not read must be tagged `map:"-"`, or dropped by
+// the method with the drop directive.
+const StrictDirective = "strict"
+
+// DropDirective acknowledges the source fields that are not read by the
+// method, e.g. //mapper:drop Password.
+const DropDirective = "drop"
+
+// NewDroppedFields returns the source fields dropped by the directives of an
+// interface method.
+func NewDroppedFields(directives []mapper.Directive) map[string]bool {
+	result := make(map[string]bool)
+	for _, d := range directives {
+		if d.Name != DropDirective {
+			continue
+		}
+		for _, name := range d.Args {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+// FieldPosition returns the position of the field of the struct T, e.g.
+// main.go:12:2, or empty if T is not declared in the files of the package
+// pkgPath.
+func FieldPosition(fset *token.FileSet, files []*ast.File, pkgPath string, T types.Type, name string) string {
+	obj := mapper.NewNamedVisitor(T).Obj()
+	if fset == nil || obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != pkgPath {
+		return ""
+	}
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok || spec.Name.Name != obj.Name() {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					for _, ident := range field.Names {
+						if ident.Name == name {
+							return relativePosition(fset.Position(ident.Pos()))
+						}
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// relativePosition returns the position relative to the working directory,
+// like the compiler errors, e.g. main.go:12:2 for go generate.
+func relativePosition(pos token.Position) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return pos.String()
+	}
+	if rel, err := filepath.Rel(wd, pos.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+		pos.Filename = rel
+	}
+	return pos.String()
+}
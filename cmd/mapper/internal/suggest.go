@@ -0,0 +1,361 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+// FieldSuggestion is the proposed mapping of a target field.
+type FieldSuggestion struct {
+	Field  mapper.StructField
+	Source string // The source field or method, empty if none is found.
+	Reason string // Why the source is proposed, e.g. tag json:"user_id".
+
+	// The funcs in scope that convert the source to the target field type.
+	Funcs []*mapper.Func
+	// The converter that is applied without tag, if any.
+	Converter *mapper.Func
+
+	tag        string     // The raw struct tag.
+	sourceType types.Type // The type of the source field or method.
+}
+
+// Tag returns the proposed map tag, or empty if the field is mapped without
+// tag.
+func (s FieldSuggestion) Tag() string {
+	if s.Source == "" {
+		return ""
+	}
+	var name, fn string
+	if s.Source != s.Field.Name {
+		name = s.Source
+	}
+	if s.Converter == nil && len(s.Funcs) > 0 {
+		fn = s.Funcs[0].Name
+		if s.Funcs[0].PkgPath != s.Field.PkgPath {
+			fn = s.Funcs[0].PkgPath + "/" + fn
+		}
+	}
+	switch {
+	case fn != "":
+		return fmt.Sprintf(`map:"%s,%s"`, name, fn)
+	case name != "":
+		return fmt.Sprintf(`map:%q`, name)
+	default:
+		return ""
+	}
+}
+
+// Suggestion proposes the map tags of the target struct, for the mapping from
+// the source struct.
+type Suggestion struct {
+	From, To *types.Named
+	Fields   []FieldSuggestion
+}
+
+type sourceField struct {
+	name string
+	tags []string // The names in the struct tags, e.g. user_id of json:"user_id".
+	typ  types.Type
+}
+
+// Suggest compares the fields of the structs from and to, and proposes the
+// source of each target field by their name, the names in their struct tags,
+// and the edit distance of their names. The funcs of the packages scopes that
+// convert the source to the target type are proposed when the types differ.
+func Suggest(from, to *types.Named, converters *Converters, scopes ...*types.Scope) (*Suggestion, error) {
+	fromStruct, ok := from.Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct", from)
+	}
+	toStruct, ok := to.Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct", to)
+	}
+
+	outgoing, err := mapper.NewStructFields(fromStruct).Outgoing()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", from, err)
+	}
+
+	var sources []sourceField
+	for name, field := range outgoing {
+		sources = append(sources, sourceField{
+			name: name,
+			tags: tagNames(fromStruct.Tag(field.Ordinal)),
+			typ:  field.Type,
+		})
+	}
+	for name, method := range mapper.NewNamedVisitor(from).Methods() {
+		if method.From != nil || method.To == nil {
+			continue
+		}
+		sources = append(sources, sourceField{name: name, typ: method.To.Type})
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].name < sources[j].name
+	})
+
+	funcs := scopeConverters(scopes)
+
+	fields := mapper.NewStructFields(toStruct)
+	result := &Suggestion{From: from, To: to}
+	for _, field := range fields {
+		s := FieldSuggestion{
+			Field: field,
+			tag:   toStruct.Tag(field.Ordinal),
+		}
+		if field.Tag != nil {
+			s.Reason = "already tagged"
+			result.Fields = append(result.Fields, s)
+			continue
+		}
+		src, reason, ok := matchSource(field.Name, tagNames(s.tag), sources)
+		if !ok {
+			result.Fields = append(result.Fields, s)
+			continue
+		}
+		s.Source, s.Reason, s.sourceType = src.name, reason, src.typ
+
+		if !mapper.IsUnderlyingIdentical(src.typ, field.Type) {
+			s.Converter, _ = converters.Find(src.typ, field.Type)
+			for _, fn := range funcs {
+				if mapper.IsIdentical(fn.From.Type, src.typ) && mapper.IsIdentical(fn.To.Type, field.Type) {
+					s.Funcs = append(s.Funcs, fn)
+				}
+			}
+		}
+		result.Fields = append(result.Fields, s)
+	}
+	sort.Slice(result.Fields, func(i, j int) bool {
+		return result.Fields[i].Field.Ordinal < result.Fields[j].Field.Ordinal
+	})
+	return result, nil
+}
+
+// matchSource returns the source with the same name, the same name in the
+// struct tags, or the closest name, in that order.
+func matchSource(name string, tags []string, sources []sourceField) (sourceField, string, bool) {
+	for _, src := range sources {
+		if src.name == name {
+			return src, "same name", true
+		}
+	}
+
+	key := normalizeName(name)
+	for _, src := range sources {
+		if normalizeName(src.name) == key {
+			return src, "same name ignoring case", true
+		}
+	}
+
+	names := append([]string{key}, tags...)
+	for _, src := range sources {
+		for _, tag := range src.tags {
+			for _, name := range names {
+				if normalizeName(tag) == normalizeName(name) {
+					return src, fmt.Sprintf("same tag name %q", tag), true
+				}
+			}
+		}
+	}
+
+	var (
+		best     sourceField
+		distance = -1
+	)
+	for _, src := range sources {
+		d := editDistance(key, normalizeName(src.name))
+		if distance == -1 || d < distance {
+			best, distance = src, d
+		}
+	}
+	// Only similar names are proposed, e.g. one or two typos.
+	if distance == -1 || distance > len(key)/3 {
+		return sourceField{}, "", false
+	}
+	return best, fmt.Sprintf("edit distance %d", distance), true
+}
+
+// scopeConverters returns the funcs in the scopes with the signature
+// func(A) B or func(A) (B, error).
+func scopeConverters(scopes []*types.Scope) []*mapper.Func {
+	var result []*mapper.Func
+	for _, scope := range scopes {
+		for _, name := range scope.Names() {
+			fn, ok := scope.Lookup(name).(*types.Func)
+			if !ok || !isConverter(fn) {
+				continue
+			}
+			result = append(result, mapper.NewFunc(fn, nil))
+		}
+	}
+	return result
+}
+
+var structTagRe = regexp.MustCompile(`(\w+):"([^"]*)"`)
+
+// tagNames returns the names of the struct tags, except the map tag, e.g.
+// user_id for `json:"user_id,omitempty"`.
+func tagNames(tag string) []string {
+	var result []string
+	for _, match := range structTagRe.FindAllStringSubmatch(tag, -1) {
+		key, name := match[1], strings.Split(match[2], ",")[0]
+		if key == "map" || name == "" || name == "-" {
+			continue
+		}
+		result = append(result, name)
+	}
+	return result
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// editDistance returns the Levenshtein distance of a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+func minInt(n int, rest ...int) int {
+	for _, m := range rest {
+		if m < n {
+			n = m
+		}
+	}
+	return n
+}
+
+// Struct prints the target struct with the proposed map tags. The fields
+// without source are commented.
+func (s *Suggestion) Struct() string {
+	qualifier := qualifierFor(s.To.Obj().Pkg())
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "type %s struct {\n", s.To.Obj().Name())
+	for _, f := range s.Fields {
+		tag := strings.TrimSpace(f.tag)
+		if t := f.Tag(); t != "" {
+			tag = strings.TrimSpace(tag + " " + t)
+		}
+		fmt.Fprintf(&b, "%s %s", f.Field.Name, types.TypeString(f.Field.Type, qualifier))
+		if tag != "" {
+			fmt.Fprintf(&b, " `%s`", tag)
+		}
+		if comment := f.comment(qualifier); comment != "" {
+			fmt.Fprintf(&b, " // %s", comment)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	return formatSource(b.String())
+}
+
+// Method prints the interface method with the proposed directives, for target
+// structs that cannot be tagged.
+func (s *Suggestion) Method() string {
+	qualifier := qualifierFor(s.From.Obj().Pkg())
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "type Mapper interface {\n")
+	for _, f := range s.Fields {
+		switch {
+		case f.Source == "" && f.Reason == "":
+			fmt.Fprintf(&b, "// TODO: no source found for %s\n//mapper:ignore %s\n", f.Field.Name, f.Field.Name)
+		case f.Source == "":
+		default:
+			var fn string
+			if f.Converter == nil && len(f.Funcs) > 0 {
+				fn = f.Funcs[0].Name
+				if f.Funcs[0].PkgPath != s.From.Obj().Pkg().Path() {
+					fn = f.Funcs[0].PkgPath + "/" + fn
+				}
+			}
+			switch {
+			case fn != "":
+				fmt.Fprintf(&b, "//mapper:field %s <- %s using %s\n", f.Field.Name, f.Source, fn)
+			case f.Source != f.Field.Name:
+				fmt.Fprintf(&b, "//mapper:field %s <- %s\n", f.Field.Name, f.Source)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%s(%s) %s\n}\n",
+		"To"+s.To.Obj().Name(),
+		types.TypeString(s.From, qualifier),
+		types.TypeString(s.To, qualifier),
+	)
+	return formatSource(b.String())
+}
+
+func (f FieldSuggestion) comment(qualifier types.Qualifier) string {
+	switch {
+	case f.Source == "" && f.Reason != "":
+		return f.Reason
+	case f.Source == "":
+		return "TODO: no source found"
+	}
+
+	var comments []string
+	if f.Source != f.Field.Name {
+		comments = append(comments, fmt.Sprintf("from %s, %s", f.Source, f.Reason))
+	}
+	switch {
+	case f.Converter != nil:
+		comments = append(comments, fmt.Sprintf("converted by %s.%s", f.Converter.Pkg, f.Converter.Name))
+	case len(f.Funcs) > 1:
+		names := make([]string, len(f.Funcs))
+		for i, fn := range f.Funcs {
+			names[i] = fn.Name
+		}
+		comments = append(comments, "candidates: "+strings.Join(names, ", "))
+	case len(f.Funcs) == 0 && !mapper.IsUnderlyingIdentical(f.sourceType, f.Field.Type):
+		comments = append(comments, fmt.Sprintf("TODO: no func found for %s to %s",
+			types.TypeString(f.sourceType, qualifier),
+			types.TypeString(f.Field.Type, qualifier),
+		))
+	}
+	return strings.Join(comments, "; ")
+}
+
+// qualifierFor qualifies the types of the other packages by the package name,
+// as they are referenced in the source code.
+func qualifierFor(pkg *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if pkg == other {
+			return ""
+		}
+		return other.Name()
+	}
+}
+
+func formatSource(src string) string {
+	b, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return string(b)
+}
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"go/types"
+	"sort"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+// FindSumMapper returns the interface method for the variant, whose result
+// can be assigned to the target interface T. The result needs to be
+// referenced if only the pointer implements T.
+func FindSumMapper(methods map[string]*mapper.Func, variant mapper.SumVariant, T types.Type) (fn *mapper.Func, ref bool, ok bool) {
+	in, ok := T.Underlying().(*types.Interface)
+	if !ok {
+		return nil, false, false
+	}
+
+	var names []string
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		norm := methods[name].Normalize()
+		if !mapper.IsUnderlyingIdentical(norm.From.Type, variant.Value()) {
+			continue
+		}
+		if types.Implements(norm.To.Type, in) {
+			return methods[name], false, true
+		}
+		if !mapper.IsPointer(norm.To.Type) && types.Implements(types.NewPointer(norm.To.Type), in) {
+			return methods[name], true, true
+		}
+	}
+	return nil, false, false
+}
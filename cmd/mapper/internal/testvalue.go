@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"go/types"
+	"strconv"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+// TestValue generates the distinct non-zero value n of the type, for the basic
+// types, and the pointers and slices of them. The strings are decimal, so that
+// they can be parsed by the tag funcs, e.g. strconv.Atoi.
+func TestValue(T types.Type, n int) (*Statement, bool) {
+	switch u := T.Underlying().(type) {
+	case *types.Basic:
+		return basicTestValue(u, n)
+	case *types.Pointer:
+		elem, ok := TestValue(u.Elem(), n)
+		if !ok {
+			return nil, false
+		}
+		// Output:
+		//
+		// func() *int {
+		//	v := int(1)
+		//	return &v
+		// }()
+		return Func().Params().Add(GenType(T)).Block(
+			Id("v").Op(":=").Add(GenType(u.Elem())).Call(elem),
+			Return(Op("&").Id("v")),
+		).Call(), true
+	case *types.Slice:
+		elem, ok := TestValue(u.Elem(), n)
+		if !ok {
+			return nil, false
+		}
+		// Output:
+		//
+		// []int{1}
+		return Add(GenType(T)).Values(elem), true
+	default:
+		return nil, false
+	}
+}
+
+func basicTestValue(T *types.Basic, n int) (*Statement, bool) {
+	info := T.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return True(), true
+	case info&types.IsString != 0:
+		return Lit(strconv.Itoa(n)), true
+	case info&types.IsInteger != 0:
+		return Lit(n), true
+	case info&types.IsFloat != 0:
+		return Lit(float64(n) + 0.5), true
+	default:
+		return nil, false
+	}
+}
+
+// FuzzInput is the fuzz argument of a field, for the basic types, and the
+// pointers and slices of them. The fuzz arguments are limited to the types
+// supported by testing.F, so the named types are converted from their
+// underlying basic type.
+type FuzzInput struct {
+	T   types.Type // The field type.
+	arg types.Type // The fuzz argument type.
+}
+
+// NewFuzzInput returns the fuzz argument of the field type, or false if the
+// type cannot be built from a fuzz argument.
+func NewFuzzInput(T types.Type) (*FuzzInput, bool) {
+	// The []byte is a fuzz argument.
+	if s, ok := T.(*types.Slice); ok {
+		if b, ok := s.Elem().(*types.Basic); ok && b.Kind() == types.Uint8 {
+			return &FuzzInput{T: T, arg: T}, true
+		}
+	}
+
+	elem := T.Underlying()
+	switch u := elem.(type) {
+	case *types.Pointer:
+		elem = u.Elem().Underlying()
+	case *types.Slice:
+		elem = u.Elem().Underlying()
+	}
+	basic, ok := elem.(*types.Basic)
+	if !ok || !isFuzzBasic(basic) {
+		return nil, false
+	}
+	return &FuzzInput{T: T, arg: types.Typ[basic.Kind()]}, true
+}
+
+func isFuzzBasic(T *types.Basic) bool {
+	switch T.Kind() {
+	case types.Bool, types.String,
+		types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+		types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64,
+		types.Float32, types.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Type returns the type of the fuzz argument.
+func (in *FuzzInput) Type() *Statement {
+	return GenType(in.arg)
+}
+
+// Seed returns the seed n of the fuzz argument, with the exact type required
+// by f.Add, e.g. int8(1).
+func (in *FuzzInput) Seed(n int) *Statement {
+	if _, ok := in.arg.(*types.Slice); ok {
+		return Index().Byte().Call(Lit(strconv.Itoa(n)))
+	}
+
+	basic := in.arg.(*types.Basic)
+	value, _ := basicTestValue(basic, n)
+	if info := basic.Info(); info&types.IsNumeric != 0 {
+		return Id(basic.Name()).Call(value)
+	}
+	return value
+}
+
+// Value returns the field value built from the fuzz argument v.
+func (in *FuzzInput) Value(v *Statement) *Statement {
+	if types.Identical(in.arg, in.T) {
+		return v
+	}
+
+	switch u := in.T.(type) {
+	case *types.Pointer:
+		if types.Identical(u.Elem(), in.arg) {
+			return Op("&").Add(v)
+		}
+		// Output:
+		//
+		// func() *Status {
+		//	v := Status(inStatus)
+		//	return &v
+		// }()
+		return Func().Params().Add(GenType(in.T)).Block(
+			Id("v").Op(":=").Add(GenType(u.Elem())).Call(v),
+			Return(Op("&").Id("v")),
+		).Call()
+	case *types.Slice:
+		// Output:
+		//
+		// []string{inTags}
+		if types.Identical(u.Elem(), in.arg) {
+			return Add(GenType(in.T)).Values(v)
+		}
+		return Add(GenType(in.T)).Values(Add(GenType(u.Elem())).Call(v))
+	default:
+		// Output:
+		//
+		// Status(inStatus)
+		return Add(GenType(in.T)).Call(v)
+	}
+}
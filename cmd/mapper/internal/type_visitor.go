@@ -25,6 +25,9 @@ func (v *TypeVisitor) Visit(T types.Type) bool {
 		v.code = v.code.Index()
 	case *types.Array:
 		v.code = v.code.Index(Lit(u.Len()))
+	case *types.Map:
+		v.code = v.code.Map(GenerateType(u.Key())).Add(GenerateType(u.Elem()))
+		return false
 	case *types.Named:
 		o := u.Obj()
 		p := o.Pkg()
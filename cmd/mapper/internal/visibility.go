@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+// Inaccessible returns the members used by the mappers that cannot be
+// referenced by code in the package pkgPath, sorted and without duplicates,
+// e.g. the source field read by two target fields. This happens when the
+// output is generated into a package different from the input.
+func (v *InterfaceVisitor) Inaccessible(pkgPath string) []string {
+	var names []string
+	for name := range v.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		result []string
+		seen   = make(map[string]bool)
+	)
+	add := func(format string, args ...interface{}) {
+		msg := fmt.Sprintf(format, args...)
+		if !seen[msg] {
+			seen[msg] = true
+			result = append(result, msg)
+		}
+	}
+	for _, name := range names {
+		fn := v.methods[name]
+		info := v.methodInfo[name]
+		param, res := info.Param, info.Result
+
+		for _, T := range []types.Type{fn.From.Type, fn.To.Type} {
+			if !mapper.IsAccessibleFrom(T, pkgPath) {
+				add("%s: type %s is unexported", fn.Name, qualifiedName(T))
+			}
+		}
+
+		fields := res.Fields()
+		sort.Strings(fields)
+		for _, field := range fields {
+			rhs, _ := res.FieldByName(field)
+			if !rhs.IsAccessibleFrom(pkgPath) {
+				add("%s: target field %s.%s is unexported", fn.Name, qualifiedName(fn.To.Type), rhs.Name)
+			}
+
+			key := rhs.Name
+			if rhs.Tag != nil && rhs.Tag.IsAlias() {
+				key = rhs.Tag.Name
+			}
+			if _, ok := param.MethodByName(key); !ok {
+				if lhs, ok := param.FieldByName(key); ok && !lhs.IsAccessibleFrom(pkgPath) {
+					if _, ok := param.GetterByField(lhs); !ok {
+						add("%s: source field %s.%s is unexported and has no getter", fn.Name, qualifiedName(fn.From.Type), lhs.Name)
+					}
+				}
+			}
+
+			tag := info.Tag(rhs)
+			if tag == nil || !tag.HasFunc() {
+				continue
+			}
+			mapperFn, _ := info.MapperByTag(tag.Tag)
+			if mapperFn.Obj != nil && !mapper.IsAccessibleFrom(mapperFn.Obj.Type(), pkgPath) {
+				add("%s: type %s in tag %q is unexported", fn.Name, qualifiedName(mapperFn.Obj.Type()), tag.Tag)
+			}
+			if !mapperFn.IsAccessibleFrom(pkgPath) {
+				add("%s: func %s in tag %q is unexported", fn.Name, mapperFn.Name, tag.Tag)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func qualifiedName(T types.Type) string {
+	return types.TypeString(mapper.NewUnderlyingType(T), (*types.Package).Name)
+}
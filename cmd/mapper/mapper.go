@@ -3,8 +3,12 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/token"
 	"go/types"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/alextanhongpin/mapper"
 	"github.com/alextanhongpin/mapper/cmd/mapper/internal"
@@ -15,6 +19,10 @@ import (
 
 const GeneratorName = "github.com/alextanhongpin/mapper"
 
+// RuntimePath is the package path of mapper.Map, which the generated mappers
+// are registered to.
+const RuntimePath = "github.com/alextanhongpin/mapper"
+
 func main() {
 	defer func() {
 		if err := recover(); err != nil {
@@ -22,6 +30,13 @@ func main() {
 		}
 	}()
 
+	if len(os.Args) > 1 && os.Args[1] == "suggest" {
+		if err := Suggest(os.Args[2:]); err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+
 	if err := mapper.New(func(opt mapper.Option) error {
 		gen := NewGenerator(opt)
 		return gen.Generate()
@@ -37,6 +52,25 @@ type Generator struct {
 	mappers          map[string]bool
 	hasErrorByMapper map[string]bool
 	interfaceVisitor *internal.InterfaceVisitor
+	pkgs             *internal.Packages // The packages of the funcs of the tags.
+	converters       *internal.Converters
+	enums            *internal.Enums
+	directives       mapper.Directives
+	fieldReports     map[string][]*internal.FieldReport // The target fields of the private mappers, for -report.
+	graph            *internal.Graph                    // The calls of the private mappers, for -graph.
+	enumMappers      map[string]*internal.EnumMapping
+	copier           *internal.Copier
+	refMappers       map[string]*internal.RefMapper
+	warnings         []string        // The target fields left at their zero value in lenient mode.
+	funcs            map[string]bool // The plain functions declared in func mode.
+	publicFuncs      map[string]publicFunc
+}
+
+// publicFunc is the public function declared in func mode by the interface,
+// which other interfaces of the package may declare identically.
+type publicFunc struct {
+	interfaceName string
+	code          string
 }
 
 func NewGenerator(opt mapper.Option) *Generator {
@@ -46,7 +80,29 @@ func NewGenerator(opt mapper.Option) *Generator {
 		dependencies:     make(map[string]types.Type),
 		mappers:          make(map[string]bool),
 		hasErrorByMapper: make(map[string]bool),
+		pkgs:             internal.NewPackages(opt.Pkg),
+		converters:       newConverters(opt),
+		enums:            internal.NewEnums(opt.Pkg, opt.Syntax),
+		directives:       mapper.NewDirectives(opt.Syntax),
+		fieldReports:     make(map[string][]*internal.FieldReport),
+		funcs:            make(map[string]bool),
+		publicFuncs:      make(map[string]publicFunc),
+	}
+}
+
+// newConverters returns the bundled converters, extended with the
+// //mapper:converter functions in the input package and the listed packages.
+func newConverters(opt mapper.Option) *internal.Converters {
+	converters := internal.DefaultConverters().Extend()
+	if opt.Pkg != nil {
+		converters.AddAnnotated(opt.Pkg, opt.Syntax)
+	}
+	for _, pkgPath := range opt.Converters {
+		if err := converters.LoadAnnotated(pkgPath); err != nil {
+			panic(err)
+		}
 	}
+	return converters
 }
 
 func (g *Generator) GenerateString() (string, error) {
@@ -56,89 +112,591 @@ func (g *Generator) GenerateString() (string, error) {
 	return g.b.String(), nil
 }
 
+// output is the generated file of one or more interfaces, and the files
+// generated next to it.
+type output struct {
+	path     string
+	items    []mapper.OptionItem
+	hash     string        // The input hash, which is recorded in the header of the file.
+	file     *jen.File     // e.g. main_gen.go
+	register *jen.File     // e.g. main_register_gen.go, with -register
+	test     *jen.File     // e.g. main_gen_test.go, with -test or -fuzz
+	extra    *bytes.Buffer // The graphs and reports, which are only rendered in dry run.
+}
+
+func (g *Generator) newOutput(path string, items []mapper.OptionItem, hash string) *output {
+	pkgPath, pkgName := g.opt.OutPkg()
+	newFile := func() *jen.File {
+		// Since a package path basename might not be the same as the package name,
+		// This allows us to use Qual and exclude imports from the same package.
+		f := NewFilePathName(pkgPath, pkgName)
+		f.HeaderComment(fmt.Sprintf("Code generated by %s, DO NOT EDIT.", GeneratorName))
+		if hash != "" {
+			f.HeaderComment(internal.InputHashPrefix + hash)
+		}
+		return f
+	}
+
+	o := &output{
+		path:  path,
+		items: items,
+		hash:  hash,
+		file:  newFile(),
+		extra: new(bytes.Buffer),
+	}
+	if g.opt.Register {
+		o.register = newFile()
+	}
+	if g.opt.Test || g.opt.Fuzz {
+		o.test = newFile()
+	}
+	return o
+}
+
+// outputPath returns the generated file of the interface, e.g. mapper_gen.go
+// for Mapper, or the file named after the input, e.g. main_gen.go, when the
+// interfaces are combined.
+func (g *Generator) outputPath(opt mapper.OptionItem) string {
+	if g.opt.Combine || opt.Path == "" {
+		return g.opt.Out
+	}
+	return opt.Path
+}
+
 func (g *Generator) Generate() error {
+	// Each interface is generated into its own file, unless the interfaces
+	// are combined, or share the output given by -out.
 	var (
-		pkgPath    = g.opt.PkgPath
-		pkgName    = g.opt.PkgName
-		interfaces = g.opt.Items
-		out        = g.opt.Out
-		dryRun     = g.opt.DryRun
+		paths  []string
+		byPath = make(map[string][]mapper.OptionItem)
 	)
+	for _, opt := range g.opt.Items {
+		path := g.outputPath(opt)
+		if _, ok := byPath[path]; !ok {
+			paths = append(paths, path)
+		}
+		byPath[path] = append(byPath[path], opt)
+	}
 
-	// If there is only one output, use the target struct
-	// name. Otherwise, the filename will be used.
-	if len(interfaces) == 1 {
-		out = interfaces[0].Path
+	// The outputs whose inputs are unchanged since they were generated are
+	// skipped, unless forced.
+	var (
+		all   []*output
+		stale = make(map[*output]bool)
+	)
+	for _, path := range paths {
+		var hash string
+		if !g.opt.DryRun {
+			hash = g.inputHash(byPath[path])
+		}
+		o := g.newOutput(path, byPath[path], hash)
+		prev, ok := internal.ReadInputHash(path)
+		stale[o] = !ok || hash == "" || prev != hash || g.opt.Force || !g.exists(o)
+		all = append(all, o)
 	}
 
-	for _, opt := range interfaces {
-		// Since a package path basename might not be the same as the package name,
-		// This allows us to use Qual and exclude imports from the same package.
-		f := NewFilePathName(pkgPath, pkgName)
-		f.HeaderComment(fmt.Sprintf("Code generated by %s, DO NOT EDIT.", GeneratorName))
+	// In func mode, the mappers shared by the interfaces are declared once in
+	// the package, by the first output that needs them. The outputs are
+	// generated together, so that a stale output does not redeclare the
+	// mappers of a skipped one.
+	var outputs, skipped []*output
+	for _, o := range all {
+		if stale[o] {
+			outputs = append(outputs, o)
+		} else {
+			skipped = append(skipped, o)
+		}
+	}
+	if len(outputs) > 0 && len(skipped) > 0 && g.sharesFuncs() {
+		outputs, skipped = all, nil
+	}
+
+	for _, o := range outputs {
+		g.prune(o)
+		for _, opt := range o.items {
+			if err := g.generateInterface(o, opt); err != nil {
+				return err
+			}
+		}
+	}
 
-		iv := internal.NewInterfaceVisitor(opt.Type)
-		interfaceMethods := iv.Methods()
-		g.interfaceVisitor = iv
+	for _, o := range outputs {
+		if err := g.save(o); err != nil {
+			return err
+		}
+	}
 
-		// Cache first so that we can re-use later.
-		var keys []string
-		for key, method := range interfaceMethods {
-			info, ok := iv.MethodInfo(method.Name)
-			if !ok {
-				panic(fmt.Errorf("method not found: %s", method.Name))
+	sort.Strings(g.warnings)
+	for _, warning := range g.warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+	for _, o := range skipped {
+		fmt.Printf("skipped: %s is up to date\n", o.path)
+	}
+	for _, o := range outputs {
+		fmt.Printf("success: generated %s\n", o.path)
+	}
+	return nil
+}
+
+// sharesFuncs returns true if the interfaces are generated as plain
+// functions, which are shared by the outputs of the package.
+func (g *Generator) sharesFuncs() bool {
+	for _, opt := range g.opt.Items {
+		if g.isFuncMode(opt) {
+			return true
+		}
+	}
+	return false
+}
+
+// exists returns true if the files generated with the output exist, so that
+// a deleted test, registration, report or graph is regenerated.
+func (g *Generator) exists(o *output) bool {
+	var paths []string
+	if g.opt.Register {
+		paths = append(paths, registrationPath(o.path))
+	}
+	if g.opt.Test || g.opt.Fuzz {
+		paths = append(paths, testPath(o.path))
+	}
+	for _, opt := range o.items {
+		path := opt.Path
+		if path == "" {
+			path = o.path
+		}
+		if g.opt.Graph != "" {
+			paths = append(paths, outputPath(path, "graph", g.opt.Graph))
+		}
+		if g.opt.Report != "" {
+			paths = append(paths, outputPath(path, "report", g.opt.Report))
+		}
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// inputHash returns the hash of the inputs of the interfaces generated into
+// the same file, which are the version of the generator, the options, the
+// directives and their funcs, the interfaces and the types they reach, and the
+// converters.
+func (g *Generator) inputHash(items []mapper.OptionItem) string {
+	h := internal.NewInputHash(g.pkgs)
+	pkgPath, pkgName := g.opt.OutPkg()
+	h.Write("package %s %s", pkgPath, pkgName)
+	h.Write("option suffix=%s copy=%s refs=%t mode=%s register=%t report=%s graph=%s test=%t fuzz=%t combine=%t",
+		g.opt.Suffix, g.opt.Copy, g.opt.Refs, g.opt.Mode, g.opt.Register, g.opt.Report, g.opt.Graph, g.opt.Test, g.opt.Fuzz, g.opt.Combine)
+
+	var names []string
+	for name := range g.directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, d := range g.directives[name] {
+			h.Write("directive %s %s", name, d)
+		}
+
+		// The funcs of the field directives, e.g. //mapper:field ID using
+		// IntToString, are resolved in the package of the interface.
+		tags, _ := internal.NewMethodTags(g.directives[name], g.opt.Pkg.Path())
+		var fields []string
+		for field := range tags {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if tag := tags[field]; tag.HasFunc() {
+				h.AddTagFunc(tag, g.opt.Pkg.Path())
 			}
-			g.hasErrorByMapper[method.Normalize().Signature()] = info.HasError()
-			keys = append(keys, key)
 		}
-		sort.Strings(keys)
+	}
+
+	for _, sig := range g.converters.Signatures() {
+		h.Write("converter %s", sig)
+	}
+
+	for _, opt := range items {
+		h.Write("interface %s %t %s", opt.Name, opt.Prototype, types.TypeString(opt.Type, nil))
+		h.AddType(opt.Type)
+	}
+	return h.Sum()
+}
 
-		/*
+// prune removes the existing files of the output before generating, so that
+// a failed generation does not leave the stale code. The files of the
+// interfaces are also removed when they are combined.
+func (g *Generator) prune(o *output) {
+	if !g.opt.Prune || g.opt.DryRun {
+		return
+	}
+	paths := []string{o.path}
+	for _, opt := range o.items {
+		if opt.Path != "" && opt.Path != o.path {
+			paths = append(paths, opt.Path)
+		}
+	}
+	for _, path := range paths {
+		// File may not exists yet, ignore.
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("error removing file %s: %s\n", path, err)
+		}
+	}
+}
 
-			Collect the generated private methods, but not build them yet,
-			mainly because there are some.
-		*/
-		var stmts []*Statement
-		for _, key := range keys {
-			method := interfaceMethods[key]
-			signature := method.Normalize().Signature()
-			if g.mappers[signature] {
+// save writes the generated files of the output, or renders them in dry run.
+func (g *Generator) save(o *output) error {
+	if g.opt.DryRun {
+		for _, f := range []*jen.File{o.file, o.register, o.test} {
+			if f == nil {
 				continue
 			}
-			stmt := g.genPrivateMethod(method, opt)
-			stmts = append(stmts, stmt)
-			g.mappers[signature] = true
+			if err := f.Render(g.b); err != nil {
+				return err
+			}
 		}
+		_, err := o.extra.WriteTo(g.b)
+		return err
+	}
+
+	// The output directory may not exist when generating into another
+	// package.
+	if err := os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return err
+	}
+	if err := o.file.Save(o.path); err != nil { // e.g. main_gen.go
+		return err
+	}
+	if o.register != nil {
+		if err := o.register.Save(registrationPath(o.path)); err != nil { // e.g. main_register_gen.go
+			return err
+		}
+	}
+	if o.test != nil {
+		if err := o.test.Save(testPath(o.path)); err != nil { // e.g. main_gen_test.go
+			return err
+		}
+	}
+	return nil
+}
 
-		// Generate the struct and constructor before the method declarations.
+// reset isolates the generation of each interface, which has its own
+// dependencies and private mappers.
+func (g *Generator) reset(opt mapper.OptionItem) {
+	pkgPath, _ := g.opt.OutPkg()
+	g.dependencies = make(map[string]types.Type)
+	g.mappers = make(map[string]bool)
+	g.hasErrorByMapper = make(map[string]bool)
+	g.fieldReports = make(map[string][]*internal.FieldReport)
+	g.enumMappers = make(map[string]*internal.EnumMapping)
+	g.copier = internal.NewCopier(pkgPath)
+	g.refMappers = make(map[string]*internal.RefMapper)
+	g.graph = internal.NewGraph(opt.Name)
+	if opt.Prototype {
+		g.graph.Interface = "mapper:func"
+	}
+}
+
+// addFunc adds the declaration of the mapper to the file. In func mode, the
+// mappers are plain functions of the package, which are shared by the
+// interfaces, and only declared once.
+func (g *Generator) addFunc(f *jen.File, opt mapper.OptionItem, name string, stmt *Statement) {
+	if g.isFuncMode(opt) {
+		if g.funcs[name] {
+			return
+		}
+		g.funcs[name] = true
+	}
+	f.Add(stmt)
+}
+
+func (g *Generator) generateInterface(o *output, opt mapper.OptionItem) error {
+	var (
+		pkgPath, _ = g.opt.OutPkg()
+		f          = o.file
+	)
+	g.reset(opt)
+
+	iv := internal.NewInterfaceVisitor(opt.Name, opt.Type, g.pkgs, g.converters, g.enums, g.directives)
+	interfaceMethods := iv.Methods()
+	g.interfaceVisitor = iv
+
+	// The output package may differ from the input package, in which case
+	// only the exported members can be referenced.
+	inaccessible := iv.Inaccessible(pkgPath)
+	if pkgPath != g.opt.PkgPath && !token.IsExported(opt.Name) {
+		inaccessible = append([]string{fmt.Sprintf("interface %s is unexported", opt.Name)}, inaccessible...)
+	}
+	if len(inaccessible) > 0 {
+		panic(fmt.Errorf("cannot generate %s in package %q\ndetail:\n- %s\nhelp: export the members, or add an exported getter for the unexported source fields",
+			g.genTypeName(opt),
+			pkgPath,
+			strings.Join(inaccessible, "\n- "),
+		))
+	}
+
+	// Cache first so that we can re-use later.
+	var keys []string
+	for key, method := range interfaceMethods {
+		info, ok := iv.MethodInfo(method.Name)
+		if !ok {
+			panic(fmt.Errorf("method not found: %s", method.Name))
+		}
+		keys = append(keys, key)
+		if unmapped := iv.Unmapped(method.Name); len(unmapped) > 0 {
+			g.warnings = append(g.warnings, fmt.Sprintf("%s leaves the fields %s of %s at their zero value",
+				method.Name,
+				strings.Join(unmapped, ", "),
+				types.TypeString(method.To.Type, (*types.Package).Name),
+			))
+		}
+		if iv.IsConfigured(method.Name) {
+			continue
+		}
+		g.hasErrorByMapper[method.Normalize().Signature()] = info.HasError()
+	}
+	sort.Strings(keys)
+
+	/*
+
+		Collect the generated private methods, but not build them yet,
+		mainly because there are some.
+	*/
+	var stmts []*Statement
+	var stmtNames []string
+	var registered []*mapper.Func
+	for _, key := range keys {
+		method := interfaceMethods[key]
+		signature := g.mapperKey(method, opt)
+		if g.mappers[signature] {
+			continue
+		}
+		g.graph.AddMethod(method.Name, iv.PrivateMethod(method).Name)
+		stmt := g.genPrivateMethod(method, opt)
+		stmts = append(stmts, stmt)
+		stmtNames = append(stmtNames, iv.PrivateMethod(method).Name)
+		g.mappers[signature] = true
+
+		// Only the private mappers shared by signature are looked up by the
+		// type pair.
+		if !iv.IsConfigured(method.Name) {
+			registered = append(registered, method.Normalize())
+		}
+	}
+
+	// The source fields read by the private mappers are known once they are
+	// generated.
+	g.checkStrict(opt, interfaceMethods, keys)
+
+	// Generate the struct and constructor before the method declarations.
+	if !g.isFuncMode(opt) {
 		g.genInterfaceChecker(f, opt)
 		g.genStruct(f, opt)
 		g.genConstructor(f, opt)
+	}
+
+	for i, stmt := range stmts {
+		g.addFunc(f, opt, stmtNames[i], stmt)
+	}
+
+	// The enum mappers are collected when generating the private methods.
+	var enumNames []string
+	for name := range g.enumMappers {
+		enumNames = append(enumNames, name)
+	}
+	sort.Strings(enumNames)
+	for _, name := range enumNames {
+		g.addFunc(f, opt, name, internal.GenEnumMethod(g.receiver(opt), g.enumMappers[name]))
+	}
 
-		for _, stmt := range stmts {
-			f.Add(stmt)
+	// The copy methods are collected when generating the private methods.
+	copyNames, copyStmts := g.copier.Methods(g.receiver(opt))
+	for i, stmt := range copyStmts {
+		g.addFunc(f, opt, copyNames[i], stmt)
+	}
+
+	for _, key := range keys {
+		method := interfaceMethods[key]
+		if !g.mappers[g.mapperKey(method, opt)] {
+			panic("method not found")
 		}
+		g.genPublicMethod(f, method, opt)
+	}
 
-		for _, key := range keys {
-			method := interfaceMethods[key]
-			if !g.mappers[method.Normalize().Signature()] {
-				panic("method not found")
-			}
-			g.genPublicMethod(f, method, opt)
+	// The ref mappers are collected when generating the private and public
+	// methods.
+	var refNames []string
+	for name := range g.refMappers {
+		refNames = append(refNames, name)
+	}
+	sort.Strings(refNames)
+	for _, name := range refNames {
+		g.addFunc(f, opt, name, internal.GenRefMethod(g.receiver(opt), g.refMappers[name]))
+	}
+
+	if o.register != nil {
+		g.genRegistration(o.register, opt, registered)
+	}
+
+	if o.test != nil {
+		g.genTests(o.test, opt, interfaceMethods, keys)
+	}
+
+	// The graph and report are generated for each interface, e.g.
+	// mapper_graph.dot for Mapper.
+	path := opt.Path
+	if path == "" {
+		path = o.path
+	}
+	if g.opt.Graph != "" {
+		b, err := g.graph.Build().Render(g.opt.Graph)
+		if err != nil {
+			return err
 		}
+		if err := g.writeExtra(o, outputPath(path, "graph", g.opt.Graph), b); err != nil { // e.g. main_graph.dot
+			return err
+		}
+	}
 
-		if dryRun {
-			if err := f.Render(g.b); err != nil {
-				return err
+	if g.opt.Report != "" {
+		b, err := g.genReport(opt, interfaceMethods, keys).Render(g.opt.Report)
+		if err != nil {
+			return err
+		}
+		if err := g.writeExtra(o, outputPath(path, "report", g.opt.Report), b); err != nil { // e.g. main_report.md
+			return err
+		}
+	}
+	return nil
+}
+
+// writeExtra writes the graph or report, which are rendered after the
+// generated files in dry run.
+func (g *Generator) writeExtra(o *output, path string, b []byte) error {
+	if g.opt.DryRun {
+		_, err := o.extra.Write(b)
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// genRegistration generates the file that registers the private mappers for
+// mapper.Map. In func mode, the mappers are registered in init.
+func (g *Generator) genRegistration(f *jen.File, opt mapper.OptionItem, methods []*mapper.Func) {
+	/*
+		Output:
+
+		// RegisterMapperImpl registers the mappers of m for mapper.Map.
+		func RegisterMapperImpl(m *MapperImpl) {
+			mapper.Register(func(a0 A) (B, error) {
+				return m.mapMainAToMainB(a0), nil
+			})
+			mapper.Register(m.mapMainCToMainD)
+		}
+	*/
+	recv := g.receiver(opt)
+	body := func(group *Group) {
+		for _, fn := range methods {
+			var (
+				in   = internal.GenInputValue(fn)
+				call = recv.Call(fn.Name)
+			)
+			// The private mapper can be registered as it is, unless the results
+			// or params differ.
+			if fn.Error && !g.opt.Refs {
+				group.Qual(RuntimePath, "Register").Call(call)
+				continue
 			}
-		} else {
-			if err := f.Save(out); err != nil { // e.g. main_gen.go
-				return err
+
+			args := []Code{in.Clone()}
+			if g.opt.Refs {
+				args = append(args, Make(Map(Interface()).Interface()))
 			}
+			group.Qual(RuntimePath, "Register").Call(
+				Func().
+					Params(internal.GenInputType(in.Clone(), fn)).
+					Params(internal.GenType(fn.To.Type), Error()).
+					BlockFunc(func(group *Group) {
+						if fn.Error {
+							group.Return(call.Call(args...))
+						} else {
+							group.Return(call.Call(args...), Nil())
+						}
+					}),
+			)
 		}
 	}
-	fmt.Printf("success: generated %s\n", out)
-	return nil
+
+	if recv.Func {
+		f.Func().Id("init").Params().BlockFunc(body)
+		return
+	}
+
+	name := "Register" + g.genTypeName(opt)
+	f.Commentf("%s registers the mappers of %s for mapper.Map.", name, recv.Name)
+	f.Func().Id(name).Params(Id(recv.Name).Op("*").Id(recv.Type)).BlockFunc(body)
+}
+
+// registrationPath returns the path of the registration file, e.g.
+// mapper_register_gen.go for mapper_gen.go.
+func registrationPath(out string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(out, ".go"), "_gen") + "_register_gen.go"
+}
+
+// genReport lists the source of the target fields of the interface methods,
+// collected when generating the private methods.
+func (g *Generator) genReport(opt mapper.OptionItem, methods map[string]*mapper.Func, keys []string) *internal.Report {
+	report := &internal.Report{Interface: opt.Name}
+	if opt.Prototype {
+		report.Interface = "mapper:func"
+	}
+	for _, key := range keys {
+		method := methods[key]
+		methodInfo, _ := g.interfaceVisitor.MethodInfo(method.Name)
+		sources := generateSortedStructFields(methodInfo.Param.StructFields())
+		report.Methods = append(report.Methods, internal.NewMethodReport(
+			method,
+			g.interfaceVisitor.PrivateMethod(method).Name,
+			g.fieldReports[g.mapperKey(method, opt)],
+			sources,
+		))
+	}
+	return report
+}
+
+// outputPath returns the path of the output of the kind next to the generated
+// file, e.g. mapper_report.md for mapper_gen.go.
+func outputPath(out, kind, format string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(out, ".go"), "_gen") + "_" + kind + "." + format
+}
+
+// transform lists the transformation of the field in the report, and the call
+// of the private mapper in the graph.
+func (g *Generator) transform(report *internal.FieldReport, mapperName, kind, name string) {
+	report.Add(kind, name)
+	g.graph.AddEdge(mapperName, name, kind)
+}
+
+// funcName returns the name of the func, qualified by the package name if it
+// is declared in another package.
+func (g *Generator) funcName(fn *mapper.Func) string {
+	if pkgPath, _ := g.opt.OutPkg(); fn.PkgPath == pkgPath {
+		return fn.Name
+	}
+	return fn.Pkg + "." + fn.Name
+}
+
+// mapperKey returns the key of the private mapper of the interface method.
+// Methods without directives share the private mapper by signature.
+func (g *Generator) mapperKey(method *mapper.Func, opt mapper.OptionItem) string {
+	if g.interfaceVisitor.IsConfigured(method.Name) {
+		return opt.Name + "." + method.Name
+	}
+	return method.Normalize().Signature()
 }
 
 func (g *Generator) dependenciesKeys() []string {
@@ -155,7 +713,7 @@ func (g *Generator) genInterfaceChecker(f *jen.File, opt mapper.OptionItem) {
 	//
 	// var _ Converter = (*ConverterImpl)(nil)
 
-	f.Var().Op("_").Id(opt.Name).Op("=").Parens(Op("*").Id(g.genTypeName(opt))).Parens(Nil())
+	f.Var().Op("_").Qual(g.opt.PkgPath, opt.Name).Op("=").Parens(Op("*").Id(g.genTypeName(opt))).Parens(Nil())
 }
 
 func (g *Generator) genStruct(f *jen.File, opt mapper.OptionItem) {
@@ -220,8 +778,7 @@ func (g *Generator) genConstructor(f *jen.File, opt mapper.OptionItem) {
 // without pointers, slice etc.
 func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *jen.Statement {
 	var (
-		typeName      = g.genTypeName(opt)
-		fnName        = fn.NormalizedName()
+		fnName        = g.interfaceVisitor.PrivateMethod(fn).Name
 		from          = fn.From
 		to            = fn.To
 		methodInfo, _ = g.interfaceVisitor.MethodInfo(fn.Name)
@@ -233,13 +790,28 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 	normFn := fn.Normalize()
 	normFn.Error = methodInfo.HasError()
 
-	// Loop through all the target keys.
-	structFields := mapper.NewStructFields(to.Type).WithTags()
+	// Loop through all the target keys. The tags may be overridden by the
+	// directives of the method.
+	structFields := methodInfo.Result.StructFields()
 	keys := generateSortedStructFields(structFields)
 
+	// The target fields that are ignored are listed in the report.
+	var reports []*internal.FieldReport
+	for name := range mapper.NewStructFields(to.Type) {
+		if _, ok := structFields[name]; !ok {
+			reports = append(reports, internal.NewFieldReport(name, "", internal.SourceIgnored))
+		}
+	}
+	defer func() {
+		g.fieldReports[g.mapperKey(fn, opt)] = reports
+	}()
+
 	m := internal.NewMulti()
 	for _, key := range keys {
-		var r internal.Resolver
+		var (
+			r      internal.Resolver
+			report *internal.FieldReport
+		)
 		// The RHS struct field.
 		to := structFields[key]
 		if to.Tag != nil && to.Tag.IsAlias() {
@@ -250,6 +822,23 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 		if field, ok := methodInfo.Param.FieldByName(key); ok {
 			// Just an ordinary LHS struct field. Noice.
 			r = internal.NewFieldResolver(from.Name, field, to)
+			report = internal.NewFieldReport(to.Name, key, internal.SourceField)
+
+			// Unexported fields cannot be read from another package, so read
+			// through the exported getter instead. The fields of protobuf messages
+			// are always read through the nil-safe getters.
+			//
+			// Input:
+			// type Lhs struct{
+			//   name string
+			// }
+			//
+			// func (l Lhs) GetName() string {}
+			if pkgPath, _ := g.opt.OutPkg(); !field.IsAccessibleFrom(pkgPath) || methodInfo.Param.IsProto() {
+				if getter, ok := methodInfo.Param.GetterByField(field); ok {
+					r = internal.NewMethodResolver(from.Name, getter, to)
+				}
+			}
 		}
 		// Has a LHS struct field, but calls the method instead.
 		// The difference is there's no custom `map` tag to tell us what method it
@@ -265,19 +854,22 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 		// LHS method can also return error as the second argument.
 		if method, ok := methodInfo.Param.MethodByName(key); ok {
 			r = internal.NewMethodResolver(from.Name, method, to)
+			report = internal.NewFieldReport(to.Name, key+"()", internal.SourceMethod)
 		}
 		if r == nil {
 			panic("not a field or method")
 		}
+		reports = append(reports, report)
 
 		var (
 			lhsType     types.Type
 			tag         = r.Tag()
 			rhsType     = r.Rhs().Type
-			hasTag      = tag != nil
+			hasTag      = tag != nil && tag.HasFunc()
 			bName       = func() *jen.Statement { return Id(r.Rhs().Name) }
 			a0Name      = r.LhsVar
 			a0Selection = r.RhsVar
+			deepCopy    = g.opt.Copy == internal.CopyDeep || (tag != nil && tag.Copy) || (r.Rhs().Tag != nil && r.Rhs().Tag.Copy)
 		)
 
 		funcBuilder := internal.NewFuncBuilder(r, normFn)
@@ -292,7 +884,11 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 			if !hasTag && !hasError && mapper.IsIdentical(lhsType, rhsType) {
 				// Output:
 				// Name: a0.Name()
-				dict[bName()] = a0Selection()
+				if deepCopy {
+					dict[bName()] = g.copier.Copy(g.receiver(opt), a0Selection(), lhsType)
+				} else {
+					dict[bName()] = a0Selection()
+				}
 				continue
 			}
 
@@ -323,8 +919,13 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 			lhs := r.Lhs().(mapper.StructField)
 			lhsType = lhs.Type
 
+			// Dereferencing the pointer requires a converter, e.g. *time.Time to
+			// time.Time.
+			_, deref := g.converters.Find(lhsType, rhsType)
+			deref = deref && mapper.IsPointer(lhsType) && !mapper.IsPointer(rhsType)
+
 			// No tags and equal types means we can assign the field directly.
-			if !hasTag && mapper.IsUnderlyingIdentical(lhsType, rhsType) {
+			if !hasTag && !deref && mapper.IsUnderlyingIdentical(lhsType, rhsType) {
 				if mapper.IsIdentical(lhsType, rhsType) {
 					/*
 						Output:
@@ -333,7 +934,13 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 							Name: a0.Name(),
 						}
 					*/
-					dict[bName()] = a0Selection()
+					if deepCopy {
+						// Output:
+						// Tags: m.copySliceString(a0.Tags),
+						dict[bName()] = g.copier.Copy(g.receiver(opt), a0Selection(), lhsType)
+					} else {
+						dict[bName()] = a0Selection()
+					}
 				} else {
 					// There may be non-pointer to pointer conversion, that wasn't
 					// handled.
@@ -360,10 +967,11 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 			// The tag defines a custom function, TransformationFunc that can be used to
 			// map LHS field to RHS.
 			if tag.IsFunc() {
-				fn, _ := methodInfo.Result.MapperByTag(tag.Tag)
+				fn, _ := methodInfo.MapperByTag(tag.Tag)
 
 				// Build the func.
 				m.Add(funcBuilder.BuildFuncCall(fn, lhsType, rhsType))
+				g.transform(report, fnName, internal.SourceTag, g.funcName(fn))
 
 				// The new type is the fn output type.
 				lhsType = fn.To.Type
@@ -372,35 +980,84 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 			// TAG: IS METHOD
 			// The tag loads a custom struct or interface method.
 			if tag.IsMethod() {
-				method, _ := methodInfo.Result.MapperByTag(tag.Tag)
+				method, _ := methodInfo.MapperByTag(tag.Tag)
+				// Plain functions have no struct to hold the dependencies.
+				if g.isFuncMode(opt) {
+					panic(internal.PrettyError(`
+						tag %q of field %q is invalid in func mode
+						detail: %s.%s is a method, which requires %s as dependency
+						hint: use a func in the tag, or generate an interface without -mode=func
+					`, tag.Tag, to.Name, tag.TypeName, method.Name, tag.TypeName))
+				}
 				// To avoid different packages having same struct name, prefix the
 				// struct name with the package name.
 				g.dependencies[tag.Var()] = method.Obj.Type()
 
 				m.Add(funcBuilder.BuildMethodCall(g.genShortName(opt).Dot(tag.Var()).Dot(method.Name), method, lhsType, rhsType))
+				g.transform(report, fnName, internal.SourceTag, tag.TypeName+"."+method.Name)
+				g.graph.AddEdge(tag.TypeName+"."+method.Name, tag.TypeName, internal.NodeDependency)
 
 				lhsType = method.To.Type
 			}
 		}
 
+		// PROTOBUF.
+		// Well-known types are converted inline, e.g. *timestamppb.Timestamp to
+		// time.Time.
+		if conv, ok := internal.NewProtoConversion(lhsType, rhsType); ok {
+			m.Add(conv(r))
+			r.Assign()
+			report.Add(internal.SourceProto, types.TypeString(lhsType, (*types.Package).Name))
+			lhsType = rhsType
+		}
+
+		// SUM TYPE.
+		// Interfaces, including protobuf oneof, are mapped to the implementation
+		// of the target interface.
+		if !mapper.IsIdentical(lhsType, rhsType) && mapper.IsSumType(lhsType) && types.IsInterface(rhsType) {
+			m.Add(g.genSumType(r, normFn, lhsType, rhsType, opt))
+			report.Add(internal.SourceSum, types.TypeString(lhsType, (*types.Package).Name))
+			lhsType = rhsType
+		}
+
+		// CONVERTER.
+		// The types differ and no tag is given, use the converter with the
+		// matching signature, e.g. sql.NullString to *string.
+		if !mapper.IsIdentical(lhsType, rhsType) && (tag == nil || !tag.HasFunc()) {
+			if fn, ok := g.converters.Find(lhsType, rhsType); ok {
+				m.Add(funcBuilder.BuildFuncCall(fn, lhsType, rhsType))
+				g.transform(report, fnName, internal.SourceConverter, g.funcName(fn))
+				lhsType = fn.To.Type
+			}
+		}
+
+		// ENUM.
+		// The constants are mapped by name in a separate method, e.g.
+		// StatusActive to APIStatusActive.
+		if !mapper.IsIdentical(lhsType, rhsType) {
+			if enum, ok := g.enums.Find(lhsType, rhsType); ok {
+				name := enum.Func.NormalizedName()
+				g.enumMappers[name] = enum
+				m.Add(funcBuilder.BuildMethodCall(g.receiver(opt).Call(name), enum.Func, lhsType, rhsType))
+				g.transform(report, fnName, internal.SourceEnum, name)
+				lhsType = rhsType
+			}
+		}
+
 		if !mapper.IsUnderlyingIdentical(lhsType, rhsType) {
 			// Check if there is a private mapper with the signature that accepts LHS
 			// and returns RHS .
 			signature := buildFnSignature(lhsType, rhsType)
 
-			var method *mapper.Func
-			interfaceMethods := mapper.NewInterfaceMethods(opt.Type)
-			for _, met := range interfaceMethods {
-				if met.Normalize().Signature() == signature {
-					method = met.Normalize()
-					// Private mapper does not have error signature.
-					// Therefor, we have to manually assign them.
-					method.Error = g.hasErrorByMapper[signature]
-					break
-				}
+			met, ok := g.interfaceVisitor.NestedMethod(signature)
+			if !ok {
+				panic(fmt.Errorf("no conversion found for field %q", to.Name))
 			}
+			method := g.privateMethod(met)
+
 			// Method found.
-			m.Add(funcBuilder.BuildMethodCall(g.genShortName(opt).Dot(method.Name), method, lhsType, rhsType))
+			m.Add(g.genMapperCall(funcBuilder, method, lhsType, rhsType, opt))
+			g.transform(report, fnName, internal.SourceMapper, method.Name)
 			lhsType = method.To.Type
 		}
 		// RETURN VALUE.
@@ -410,14 +1067,23 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 
 	return internal.NewMulti(
 		Func().
-			Params(g.genShortName(opt).Op("*").Id(typeName)).                      // (m *Converter)
-			Id(fnName).                                                            // mapMainAToMainB
-			Params(internal.GenInputType(internal.GenInputValue(normFn), normFn)). // (a A)
+			Add(g.receiver(opt).Params()). // (m *Converter)
+			Id(fnName).                    // mapMainAToMainB
+			ParamsFunc(func(group *Group) {
+				group.Add(internal.GenInputType(internal.GenInputValue(normFn), normFn)) // (a A)
+				if g.opt.Refs {
+					group.Add(internal.GenVisitedParam())
+				}
+			}).
 			Add(internal.GenReturnType(normFn)).
 			BlockFunc(func(g *Group) {
 				g.Add(m.Statement())
 
 				returnType := internal.GenTypeName(to.Type).Values(dict)
+				if mapper.IsPointer(normFn.To.Type) {
+					// Protobuf messages are returned as pointer.
+					returnType = Op("&").Add(returnType)
+				}
 
 				if normFn.Error {
 					g.Add(Return(List(returnType, Nil())))
@@ -428,11 +1094,139 @@ func (g *Generator) genPrivateMethod(fn *mapper.Func, opt mapper.OptionItem) *je
 		Line()
 }
 
+// privateMethod returns the private mapper of the interface method, with the
+// error of the private mapper, which may differ from the method.
+func (g *Generator) privateMethod(fn *mapper.Func) *mapper.Func {
+	method := g.interfaceVisitor.PrivateMethod(fn)
+	if info, ok := g.interfaceVisitor.MethodInfo(fn.Name); ok && g.interfaceVisitor.IsConfigured(fn.Name) {
+		method.Error = info.HasError()
+		return method
+	}
+	method.Error = g.hasErrorByMapper[method.Signature()]
+	return method
+}
+
+// genMapperCall builds the call to the private mapper. With -refs, the visited
+// references are passed, and pointers are mapped by the ref mapper to preserve
+// the shared references.
+func (g *Generator) genMapperCall(b *internal.FuncBuilder, method *mapper.Func, lhsType, rhsType types.Type, opt mapper.OptionItem) *jen.Statement {
+	if !g.opt.Refs {
+		return b.BuildMethodCall(g.receiver(opt).Call(method.Name), method, lhsType, rhsType)
+	}
+
+	if internal.IsRef(lhsType, rhsType) {
+		if ref, ok := internal.NewRefMapper(method); ok {
+			g.refMappers[ref.Func.Name] = ref
+			return b.BuildMethodCall(g.receiver(opt).Call(ref.Func.Name), ref.Func, lhsType, rhsType, Id(internal.Visited))
+		}
+	}
+	return b.BuildMethodCall(g.receiver(opt).Call(method.Name), method, lhsType, rhsType, Id(internal.Visited))
+}
+
+// genSumType generates the type switch that maps each variant of the interface
+// to the implementation of the target interface. For protobuf oneof, the
+// variants are the wrapper types.
+func (g *Generator) genSumType(r internal.Resolver, fn *mapper.Func, lhsType, rhsType types.Type, opt mapper.OptionItem) *jen.Statement {
+	/*
+		Output:
+
+		var a0Payment PaymentMethod
+		switch v := a0.GetPayment().(type) {
+		case nil:
+		case *pb.Order_Card:
+			if v != nil {
+				a0Payment = m.mapPbCardToMainCard(v.Card)
+			}
+		case *pb.Order_BankTransfer:
+			if v != nil {
+				tmp, err := m.mapPbBankTransferToMainBankTransfer(v.BankTransfer)
+				if err != nil {
+					return Order{}, err
+				}
+				a0Payment = &tmp
+			}
+		default:
+			return Order{}, fmt.Errorf("mapper: unhandled variant %T of pb.isOrder_Payment", v)
+		}
+
+		The variants that are not declared in the package of the interface are
+		unhandled, and fail the mapper, or panic if the mapper does not return
+		error. The nil pointers of the variants are mapped to nil.
+	*/
+	var (
+		a0Name           = r.LhsVar
+		a0Selection      = r.RhsVar
+		interfaceMethods = mapper.NewInterfaceMethods(opt.Type)
+	)
+	defer r.Assign()
+
+	return internal.NewMulti(
+		Var().Add(a0Name()).Add(internal.GenType(rhsType)),
+		Switch(Id("v").Op(":=").Add(a0Selection()).Assert(Type())).BlockFunc(func(group *Group) {
+			group.Case(Nil())
+			for _, variant := range mapper.SumVariants(lhsType) {
+				met, ref, ok := internal.FindSumMapper(interfaceMethods, variant, rhsType)
+				if !ok {
+					continue
+				}
+				method := g.privateMethod(met)
+
+				call := g.receiver(opt).Call(method.Name).Call(Do(func(s *Statement) {
+					if method.RequiresInputValue(variant.Value()) {
+						s.Add(Op("*"))
+					}
+				}).Id("v").Do(func(s *Statement) {
+					if variant.Field != nil {
+						s.Dot(variant.Field.Name())
+					}
+				}), Do(func(s *Statement) {
+					if g.opt.Refs {
+						s.Id(internal.Visited)
+					}
+				}))
+
+				assign := func(group *Group) {
+					switch {
+					case method.Error:
+						group.List(Id("tmp"), Err()).Op(":=").Add(call)
+						group.Add(internal.GenReturnValue(fn))
+						group.Add(a0Name()).Op("=").Do(func(s *Statement) {
+							if ref {
+								s.Add(Op("&"))
+							}
+						}).Id("tmp")
+					case ref:
+						group.Id("tmp").Op(":=").Add(call)
+						group.Add(a0Name()).Op("=").Op("&").Id("tmp")
+					default:
+						group.Add(a0Name()).Op("=").Add(call)
+					}
+				}
+
+				group.Case(internal.GenType(variant.Type)).BlockFunc(func(group *Group) {
+					// The pointer is dereferenced, or its field is read.
+					if method.RequiresInputValue(variant.Value()) || variant.Field != nil {
+						group.If(Id("v").Op("!=").Nil()).BlockFunc(assign)
+						return
+					}
+					assign(group)
+				})
+			}
+
+			msg := Lit("mapper: unhandled variant %T of " + types.TypeString(lhsType, (*types.Package).Name))
+			if fn.Error {
+				group.Default().Block(Return(internal.GenerateOutputType(fn.To.Type, false), Qual("fmt", "Errorf").Call(msg, Id("v"))))
+			} else {
+				group.Default().Block(Panic(Qual("fmt", "Sprintf").Call(msg, Id("v"))))
+			}
+		}),
+	).Statement()
+}
+
 func (g *Generator) genPublicMethod(f *jen.File, fn *mapper.Func, opt mapper.OptionItem) {
 	var (
-		typeName = g.genTypeName(opt)
-		lhsType  = fn.From.Type
-		rhsType  = fn.To.Type
+		lhsType = fn.From.Type
+		rhsType = fn.To.Type
 	)
 
 	lhs := mapper.StructField{
@@ -449,30 +1243,97 @@ func (g *Generator) genPublicMethod(f *jen.File, fn *mapper.Func, opt mapper.Opt
 	res.Assign()
 	funcBuilder := internal.NewFuncBuilder(res, fn)
 
-	normFn := fn.Normalize()
+	normFn := g.interfaceVisitor.PrivateMethod(fn)
 	normFn.Error = g.hasErrorByMapper[fn.Normalize().Signature()]
-	method := funcBuilder.BuildMethodCall(g.genShortName(opt).Dot(normFn.NormalizedName()), normFn, lhsType, rhsType)
+	if info, _ := g.interfaceVisitor.MethodInfo(fn.Name); g.interfaceVisitor.IsConfigured(fn.Name) {
+		normFn.Error = info.HasError()
+	}
+	method := g.genMapperCall(funcBuilder, normFn, lhsType, rhsType, opt)
 
-	f.Func().
-		Params(g.genShortName(opt).Op("*").Id(typeName)). // (m *Converter)
+	body := func(group *Group) {
+		if g.opt.Refs {
+			group.Add(internal.GenVisitedVar())
+		}
+		group.Add(method)
+
+		if fn.Error {
+			group.Add(Return(List(res.RhsVar(), Nil())))
+		} else {
+			group.Add(Return(res.RhsVar()))
+		}
+	}
+
+	if opt.Prototype {
+		// The //mapper:func var is assigned, since it cannot be redeclared.
+		//
+		// Output:
+		//
+		// func init() {
+		//	ToDTO = func(u0 User) UserDTO {
+		//		...
+		//	}
+		// }
+		f.Func().Id("init").Params().Block(
+			Id(fn.Name).Op("=").Func().
+				Params(internal.GenInputType(arg, fn)).
+				Add(funcBuilder.GenReturnType()).
+				BlockFunc(body),
+		).Line()
+		return
+	}
+
+	stmt := Func().
+		Add(g.receiver(opt).Params()). // (m *Converter)
 		Id(fn.Name).
 		Params(internal.GenInputType(arg, fn)). // Convert(a *A)
 		Add(funcBuilder.GenReturnType()).       // (*B, error)
-		BlockFunc(func(g *Group) {
-			g.Add(method)
+		BlockFunc(body).Line()
+	if g.isFuncMode(opt) && !g.addPublicFunc(opt, fn, stmt) {
+		return
+	}
+	f.Add(stmt)
+}
 
-			if fn.Error {
-				g.Add(Return(List(res.RhsVar(), Nil())))
-			} else {
-				g.Add(Return(res.RhsVar()))
-			}
-		}).Line()
+// addPublicFunc returns false if the public function is already declared in
+// func mode by another interface of the package, e.g. ToAddressDTO of
+// UserMapper and OrderMapper. The identical functions are declared once, and
+// the others would redeclare the function.
+func (g *Generator) addPublicFunc(opt mapper.OptionItem, fn *mapper.Func, stmt *Statement) bool {
+	code := fmt.Sprintf("%#v", stmt)
+	prev, ok := g.publicFuncs[fn.Name]
+	if !ok {
+		g.publicFuncs[fn.Name] = publicFunc{interfaceName: opt.Name, code: code}
+		return true
+	}
+	if prev.code == code {
+		return false
+	}
+	panic(fmt.Errorf("func %s is declared by interfaces %s and %s with different mappings\ndetail: the funcs of the interfaces are declared in package %s in func mode\nhelp: rename one of the methods, or generate the interfaces in method mode",
+		fn.Name, prev.interfaceName, opt.Name, g.opt.PkgName))
 }
 
 func (g *Generator) genTypeName(opt mapper.OptionItem) string {
 	return opt.Name + g.opt.Suffix
 }
 
+// isFuncMode returns true if the mappers are generated as plain functions,
+// instead of the methods of the struct implementing the interface.
+func (g *Generator) isFuncMode(opt mapper.OptionItem) bool {
+	return g.opt.Mode == mapper.ModeFunc || opt.Prototype
+}
+
+// receiver returns the receiver of the generated mappers, which are plain
+// functions in func mode.
+func (g *Generator) receiver(opt mapper.OptionItem) internal.Receiver {
+	if g.isFuncMode(opt) {
+		return internal.Receiver{Func: true}
+	}
+	return internal.Receiver{
+		Name: loader.ShortName(g.genTypeName(opt)),
+		Type: g.genTypeName(opt),
+	}
+}
+
 func (g *Generator) genShortName(opt mapper.OptionItem) *Statement {
 	return Id(loader.ShortName(g.genTypeName(opt)))
 }
@@ -1,9 +1,19 @@
 package main
 
 import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/cmd/mapper/internal"
 	"github.com/alextanhongpin/mapper/loader"
 	"github.com/google/go-cmp/cmp"
 )
@@ -72,5 +82,2572 @@ func TestMapper(t *testing.T) {
 	}
 }
 
-/*
- */
+var sourceTagProgram = `
+package main
+
+type Mapper interface {
+	Map(A) B
+}
+
+type A struct {
+	ID       int    ` + "`map:\"ExternalID\"`" + `
+	Name     string
+	Password string ` + "`map:\"-\"`" + `
+}
+
+type B struct {
+	ExternalID int
+	FullName   string ` + "`map:\"Name\"`" + `
+}
+`
+
+var sourceTagGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainAToMainB(a0 A) B {
+	return B{
+		ExternalID: a0.ID,
+		FullName:   a0.Name,
+	}
+}
+
+func (m *Mapper) Map(a0 A) B {
+	a1 := m.mapMainAToMainB(a0)
+	return a1
+}
+`
+
+func TestMapperSourceTag(t *testing.T) {
+	res := generateString(t, sourceTagProgram, "Mapper")
+	if diff := cmp.Diff(res, sourceTagGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestMapperSourceTagConflict(t *testing.T) {
+	program := strings.Replace(sourceTagProgram, "Name     string\n", "Name     string `map:\"ExternalID\"`\n", 1)
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		expected := "invalid source struct main.A\ndetail: fields ID and Name are both exposed as ExternalID\nhelp: rename the alias in the map tag of one of the fields, or ignore it with `map:\"-\"`"
+		if diff := cmp.Diff(expected, err.Error()); diff != "" {
+			t.Fatal(diff)
+		}
+	}()
+	generateString(t, program, "Mapper")
+}
+
+var targetTagProgram = `
+package main
+
+import "strconv"
+
+type Mapper interface {
+	ToB(A) B
+	ToA(B) A
+}
+
+type A struct {
+	ID int ` + "`map:\",StringToInt\"`" + `
+}
+
+type B struct {
+	ID string ` + "`map:\",IntToString\"`" + `
+}
+
+func IntToString(i int) string {
+	return strconv.Itoa(i)
+}
+
+func StringToInt(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
+`
+
+func TestMapperTargetTag(t *testing.T) {
+	// Each tag converts to its own struct, and is not resolved as a source
+	// func when the target declares one.
+	res := generateString(t, targetTagProgram, "Mapper")
+	for _, want := range []string{
+		"IntToString(a0.ID)",
+		"StringToInt(b0.ID)",
+	} {
+		if !strings.Contains(res, want) {
+			t.Errorf("missing %q in:\n%s", want, res)
+		}
+	}
+}
+
+var inaccessibleProgram = `
+package main
+
+type Mapper interface {
+	Map(A) B
+}
+
+type A struct {
+	id   int
+	name string
+}
+
+func (a A) GetName() string { return a.name }
+
+type B struct {
+	ID   int    ` + "`map:\"id\"`" + `
+	Name string ` + "`map:\"name\"`" + `
+	age  int    ` + "`map:\"id\"`" + `
+}
+`
+
+func TestMapperInaccessible(t *testing.T) {
+	pkg := loader.LoadPackageString(inaccessibleProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:        pkg,
+		PkgName:    pkg.Name(),
+		PkgPath:    pkg.Path(),
+		OutPkgName: "out",
+		OutPkgPath: "cmd/out",
+		DryRun:     true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		expected := `cannot generate Mapper in package "cmd/out"
+detail:
+- Map: source field main.A.id is unexported and has no getter
+- Map: target field main.B.age is unexported
+help: export the members, or add an exported getter for the unexported source fields`
+		if diff := cmp.Diff(expected, err.Error()); diff != "" {
+			t.Fatal(diff)
+		}
+	}()
+	_, _ = gen.GenerateString()
+}
+
+var protoProgram = `
+package main
+
+type Mapper interface {
+	FromProto(*UserProto) User
+	ToProto(User) *UserProto
+}
+
+type MessageState struct{}
+
+type UserProto struct {
+	state         MessageState
+	sizeCache     int32
+	unknownFields []byte
+
+	Name string
+}
+
+func (x *UserProto) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type User struct {
+	Name string
+}
+`
+
+var protoGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainUserProtoToMainUser(u0 *UserProto) User {
+	return User{Name: u0.GetName()}
+}
+
+func (m *Mapper) mapMainUserToMainUserProto(u0 User) *UserProto {
+	return &UserProto{Name: u0.Name}
+}
+
+func (m *Mapper) FromProto(u0 *UserProto) User {
+	u1 := m.mapMainUserProtoToMainUser(u0)
+	return u1
+}
+
+func (m *Mapper) ToProto(u0 User) *UserProto {
+	u1 := m.mapMainUserToMainUserProto(u0)
+	return u1
+}
+`
+
+func TestMapperProto(t *testing.T) {
+	res := generateString(t, protoProgram, "Mapper")
+	if diff := cmp.Diff(res, protoGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func generateString(t *testing.T, program string, typeName string) string {
+	t.Helper()
+
+	pkg, syntax := loader.LoadPackageStringSyntax(program)
+	obj := pkg.Scope().Lookup(typeName)
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: typeName,
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+var converterProgram = `
+package main
+
+import "fmt"
+
+type Mapper interface {
+	Map(A) B
+}
+
+type Cents int64
+
+type Money string
+
+//mapper:converter
+func CentsToMoney(c Cents) Money {
+	return Money(fmt.Sprintf("%.2f", float64(c)/100))
+}
+
+type A struct {
+	Price Cents
+}
+
+type B struct {
+	Price Money
+}
+`
+
+var converterGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainAToMainB(a0 A) B {
+	a0Price := CentsToMoney(a0.Price)
+	return B{Price: a0Price}
+}
+
+func (m *Mapper) Map(a0 A) B {
+	a1 := m.mapMainAToMainB(a0)
+	return a1
+}
+`
+
+func TestMapperConverter(t *testing.T) {
+	res := generateString(t, converterProgram, "Mapper")
+	if diff := cmp.Diff(res, converterGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestMapperConverterAmbiguous(t *testing.T) {
+	program := converterProgram + `
+//mapper:converter
+func FormatCents(c Cents) Money {
+	return Money(fmt.Sprint(c))
+}
+`
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		expected := `converter "FormatCents" is ambiguous
+detail: "cmd/hello.CentsToMoney" and "cmd/hello.FormatCents" both convert cmd/hello.Cents -> cmd/hello.Money
+hint: remove one of the converters, or use the map tag on the field instead`
+		if diff := cmp.Diff(expected, err.Error()); diff != "" {
+			t.Fatal(diff)
+		}
+	}()
+	generateString(t, program, "Mapper")
+}
+
+var converterPointerProgram = `
+package main
+
+import "errors"
+
+type Mapper interface {
+	ToB(A) (B, error)
+}
+
+//mapper:converter
+func DerefInt(p *int) (int, error) {
+	if p == nil {
+		return 0, errors.New("nil")
+	}
+	return *p, nil
+}
+
+type A struct {
+	Count *int
+}
+
+type B struct {
+	Count int
+}
+`
+
+var converterPointerGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainAToMainB(a0 A) (B, error) {
+	a0Count, err := DerefInt(a0.Count)
+	if err != nil {
+		return B{}, err
+	}
+	return B{Count: a0Count}, nil
+}
+
+func (m *Mapper) ToB(a0 A) (B, error) {
+	a1, err := m.mapMainAToMainB(a0)
+	if err != nil {
+		return B{}, err
+	}
+	return a1, nil
+}
+`
+
+func TestMapperConverterPointerError(t *testing.T) {
+	// The pointer is dereferenced by the converter, which returns error.
+	res := generateString(t, converterPointerProgram, "Mapper")
+	if diff := cmp.Diff(res, converterPointerGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestMapperConverterPointerMissingError(t *testing.T) {
+	program := strings.Replace(converterPointerProgram, "ToB(A) (B, error)", "ToB(A) B", 1)
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		expected := `function "func ToB(main.A) main.B" is missing error return
+detail: converter "func DerefInt(p *int) (int, error)" for field "Count" returns error
+hint: add error return`
+		if diff := cmp.Diff(expected, err.Error()); diff != "" {
+			t.Fatal(diff)
+		}
+	}()
+	generateString(t, program, "Mapper")
+}
+
+var enumProgram = `
+package main
+
+type Mapper interface {
+	Map(A) (B, error)
+}
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusBanned //mapper:enum APIStatus_SUSPENDED
+	StatusPending
+)
+
+type APIStatus string
+
+const (
+	APIStatus_UNSPECIFIED APIStatus = ""
+	APIStatus_ACTIVE      APIStatus = "active"
+	APIStatus_SUSPENDED   APIStatus = "suspended"
+)
+
+type A struct {
+	Status Status
+}
+
+type B struct {
+	Status APIStatus
+}
+`
+
+var enumGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+import "fmt"
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainAToMainB(a0 A) (B, error) {
+	a0Status, err := m.mapMainStatusToMainAPIStatus(a0.Status)
+	if err != nil {
+		return B{}, err
+	}
+	return B{Status: a0Status}, nil
+}
+
+func (m *Mapper) mapMainStatusToMainAPIStatus(s0 Status) (APIStatus, error) {
+	switch s0 {
+	case StatusActive:
+		return APIStatus_ACTIVE, nil
+	case StatusBanned:
+		return APIStatus_SUSPENDED, nil
+	default:
+		return "", fmt.Errorf("mapper: unknown main.Status %v", s0)
+	}
+}
+
+func (m *Mapper) Map(a0 A) (B, error) {
+	a1, err := m.mapMainAToMainB(a0)
+	if err != nil {
+		return B{}, err
+	}
+	return a1, nil
+}
+`
+
+func TestMapperEnum(t *testing.T) {
+	res := generateString(t, enumProgram, "Mapper")
+	if diff := cmp.Diff(res, enumGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var sumTypeProgram = `
+package main
+
+type Mapper interface {
+	Map(Order) APIOrder
+	MapCard(Card) APICard
+	MapBankTransfer(BankTransfer) APIBankTransfer
+}
+
+type PaymentMethod interface {
+	isPaymentMethod()
+}
+
+type Card struct {
+	Number string
+}
+
+func (Card) isPaymentMethod() {}
+
+type BankTransfer struct {
+	IBAN string
+}
+
+func (*BankTransfer) isPaymentMethod() {}
+
+type Order struct {
+	Payment PaymentMethod
+}
+
+type APIPaymentMethod interface {
+	PaymentType() string
+}
+
+type APICard struct {
+	Number string
+}
+
+func (APICard) PaymentType() string { return "card" }
+
+type APIBankTransfer struct {
+	IBAN string
+}
+
+func (*APIBankTransfer) PaymentType() string { return "bank_transfer" }
+
+type APIOrder struct {
+	Payment APIPaymentMethod
+}
+`
+
+var sumTypeGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+import "fmt"
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainOrderToMainAPIOrder(o0 Order) APIOrder {
+	var o0Payment APIPaymentMethod
+	switch v := o0.Payment.(type) {
+	case nil:
+	case *BankTransfer:
+		if v != nil {
+			tmp := m.mapMainBankTransferToMainAPIBankTransfer(*v)
+			o0Payment = &tmp
+		}
+	case Card:
+		o0Payment = m.mapMainCardToMainAPICard(v)
+	case *Card:
+		if v != nil {
+			o0Payment = m.mapMainCardToMainAPICard(*v)
+		}
+	default:
+		panic(fmt.Sprintf("mapper: unhandled variant %T of main.PaymentMethod", v))
+	}
+	return APIOrder{Payment: o0Payment}
+}
+
+func (m *Mapper) mapMainBankTransferToMainAPIBankTransfer(b0 BankTransfer) APIBankTransfer {
+	return APIBankTransfer{IBAN: b0.IBAN}
+}
+
+func (m *Mapper) mapMainCardToMainAPICard(c0 Card) APICard {
+	return APICard{Number: c0.Number}
+}
+
+func (m *Mapper) Map(o0 Order) APIOrder {
+	o1 := m.mapMainOrderToMainAPIOrder(o0)
+	return o1
+}
+
+func (m *Mapper) MapBankTransfer(b0 BankTransfer) APIBankTransfer {
+	b1 := m.mapMainBankTransferToMainAPIBankTransfer(b0)
+	return b1
+}
+
+func (m *Mapper) MapCard(c0 Card) APICard {
+	c1 := m.mapMainCardToMainAPICard(c0)
+	return c1
+}
+`
+
+func TestMapperSumType(t *testing.T) {
+	res := generateString(t, sumTypeProgram, "Mapper")
+	if diff := cmp.Diff(res, sumTypeGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var deepCopyProgram = `
+package main
+
+type Mapper interface {
+	Map(A) B
+}
+
+type Address struct {
+	Lines []string
+	Zip   string
+}
+
+type A struct {
+	Name    string
+	Tags    []string
+	Address *Address
+}
+
+type B struct {
+	Name    string
+	Tags    []string
+	Address *Address
+}
+`
+
+var deepCopyGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainAToMainB(a0 A) B {
+	return B{
+		Address: m.copyPointerMainAddress(a0.Address),
+		Name:    a0.Name,
+		Tags:    m.copySliceString(a0.Tags),
+	}
+}
+
+func (m *Mapper) copyMainAddress(in Address) Address {
+	out := in
+	out.Lines = m.copySliceString(in.Lines)
+	return out
+}
+
+func (m *Mapper) copyPointerMainAddress(in *Address) *Address {
+	if in == nil {
+		return nil
+	}
+	out := m.copyMainAddress(*in)
+	return &out
+}
+
+func (m *Mapper) copySliceString(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
+
+func (m *Mapper) Map(a0 A) B {
+	a1 := m.mapMainAToMainB(a0)
+	return a1
+}
+`
+
+func TestMapperDeepCopy(t *testing.T) {
+	pkg := loader.LoadPackageString(deepCopyProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Copy:    "deep",
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(res, deepCopyGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var deepCopyPackageProgram = `
+package main
+
+import (
+	htmltemplate "html/template"
+	"text/template"
+)
+
+type Mapper interface {
+	Map(A) B
+}
+
+type A struct {
+	Text *template.Template
+	HTML *htmltemplate.Template
+}
+
+type B struct {
+	Text *template.Template
+	HTML *htmltemplate.Template
+}
+`
+
+func TestMapperDeepCopyPackageName(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(deepCopyPackageProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Copy:    "deep",
+		Suffix:  "Impl",
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"copyPointerTextTemplateTemplate(a0.Text)",
+		"copyPointerHtmlTemplateTemplate(a0.HTML)",
+	} {
+		if !strings.Contains(res, name) {
+			t.Errorf("missing %s in:\n%s", name, res)
+		}
+	}
+	typeCheck(t, deepCopyPackageProgram, res)
+}
+
+var refsProgram = `
+package main
+
+type Mapper interface {
+	Map(*Node) *NodeDTO
+	MapTree(Tree) (TreeDTO, error)
+}
+
+type Node struct {
+	Name     string
+	Parent   *Node
+	Children []*Node
+}
+
+type NodeDTO struct {
+	Name     string
+	Parent   *NodeDTO
+	Children []*NodeDTO
+}
+
+type Tree struct {
+	Root *Node
+	All  []Node
+}
+
+type TreeDTO struct {
+	Root *NodeDTO
+	All  []NodeDTO
+}
+`
+
+var refsGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainNodeToMainNodeDTO(n0 Node, visited map[interface{}]interface{}) NodeDTO {
+	n0Children := make([]*NodeDTO, len(n0.Children))
+	for i, each := range n0.Children {
+		n0Children[i] = m.mapRefMainNodeToMainNodeDTO(each, visited)
+	}
+	n0Parent := m.mapRefMainNodeToMainNodeDTO(n0.Parent, visited)
+	return NodeDTO{
+		Children: n0Children,
+		Name:     n0.Name,
+		Parent:   n0Parent,
+	}
+}
+
+func (m *Mapper) mapMainTreeToMainTreeDTO(t0 Tree, visited map[interface{}]interface{}) TreeDTO {
+	t0All := make([]NodeDTO, len(t0.All))
+	for i, each := range t0.All {
+		t0All[i] = m.mapMainNodeToMainNodeDTO(each, visited)
+	}
+	t0Root := m.mapRefMainNodeToMainNodeDTO(t0.Root, visited)
+	return TreeDTO{
+		All:  t0All,
+		Root: t0Root,
+	}
+}
+
+func (m *Mapper) Map(n0 *Node) *NodeDTO {
+	visited := make(map[interface{}]interface{})
+	n1 := m.mapRefMainNodeToMainNodeDTO(n0, visited)
+	return n1
+}
+
+func (m *Mapper) MapTree(t0 Tree) (TreeDTO, error) {
+	visited := make(map[interface{}]interface{})
+	t1 := m.mapMainTreeToMainTreeDTO(t0, visited)
+	return t1, nil
+}
+
+func (m *Mapper) mapRefMainNodeToMainNodeDTO(n0 *Node, visited map[interface{}]interface{}) *NodeDTO {
+	if n0 == nil {
+		return nil
+	}
+	key := [2]interface{}{n0, (*NodeDTO)(nil)}
+	if n1, ok := visited[key]; ok {
+		return n1.(*NodeDTO)
+	}
+	n1 := new(NodeDTO)
+	visited[key] = n1
+	*n1 = m.mapMainNodeToMainNodeDTO(*n0, visited)
+	return n1
+}
+`
+
+func TestMapperRefs(t *testing.T) {
+	pkg := loader.LoadPackageString(refsProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Refs:    true,
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(res, refsGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var methodDirectiveProgram = `
+package main
+
+type Mapper interface {
+	Map(User) External
+
+	//mapper:field Name <- Email
+	//mapper:ignore Password
+	MapPublic(User) External
+	MapPublics([]User) []External
+}
+
+type User struct {
+	ID       int
+	Name     string
+	Email    string
+	Password string
+}
+
+type External struct {
+	ID       int
+	Name     string
+	Password string
+}
+`
+
+var methodDirectiveGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainUserToMainExternal(u0 User) External {
+	return External{
+		ID:       u0.ID,
+		Name:     u0.Name,
+		Password: u0.Password,
+	}
+}
+
+func (m *Mapper) mapMapPublic(u0 User) External {
+	return External{
+		ID:   u0.ID,
+		Name: u0.Email,
+	}
+}
+
+func (m *Mapper) Map(u0 User) External {
+	u1 := m.mapMainUserToMainExternal(u0)
+	return u1
+}
+
+func (m *Mapper) MapPublic(u0 User) External {
+	u1 := m.mapMapPublic(u0)
+	return u1
+}
+
+func (m *Mapper) MapPublics(u0 []User) []External {
+	u1 := make([]External, len(u0))
+	for i, each := range u0 {
+		u1[i] = m.mapMainUserToMainExternal(each)
+	}
+	return u1
+}
+`
+
+func TestMapperMethodDirective(t *testing.T) {
+	res := generateString(t, methodDirectiveProgram, "Mapper")
+	if diff := cmp.Diff(res, methodDirectiveGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestMapperMethodDirectiveInvalid(t *testing.T) {
+	program := strings.Replace(methodDirectiveProgram, "//mapper:ignore Password", "//mapper:ignore Secret", 1)
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		expected := `method "func MapPublic(main.User) main.External" has invalid directive
+detail: field "Secret" not found
+hint: use //mapper:field Target <- Source using Func, or //mapper:ignore Target`
+		if diff := cmp.Diff(expected, err.Error()); diff != "" {
+			t.Fatal(diff)
+		}
+	}()
+	generateString(t, program, "Mapper")
+}
+
+var methodDirectiveNestedProgram = `
+package main
+
+type Mapper interface {
+	//mapper:field Nick <- Name
+	ToDTO(User) UserDTO
+	ToOrder(Order) OrderDTO
+}
+
+type User struct {
+	Name string
+}
+
+type UserDTO struct {
+	Nick string
+}
+
+type Order struct {
+	User User
+}
+
+type OrderDTO struct {
+	User UserDTO
+}
+`
+
+func TestMapperMethodDirectiveNested(t *testing.T) {
+	// The configured method maps the nested pair it does not own, and is
+	// called by its private mapper.
+	res := generateString(t, methodDirectiveNestedProgram, "Mapper")
+	for _, want := range []string{
+		"func (m *Mapper) mapToDTO(u0 User) UserDTO {\n\treturn UserDTO{Nick: u0.Name}\n}",
+		"o0User := m.mapToDTO(o0.User)",
+	} {
+		if !strings.Contains(res, want) {
+			t.Errorf("missing %q in:\n%s", want, res)
+		}
+	}
+}
+
+var funcModeProgram = `
+package main
+
+type Mapper interface {
+	ToDTO(User) UserDTO
+	ToDTOs([]User) []UserDTO
+}
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusBanned
+)
+
+type DTOStatus string
+
+const (
+	DTOStatusActive DTOStatus = "active"
+	DTOStatusBanned DTOStatus = "banned" //mapper:enum default
+)
+
+type User struct {
+	Name   string
+	Status Status
+}
+
+type UserDTO struct {
+	Name   string
+	Status DTOStatus
+}
+`
+
+var funcModeGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+func mapMainUserToMainUserDTO(u0 User) UserDTO {
+	u0Status := mapMainStatusToMainDTOStatus(u0.Status)
+	return UserDTO{
+		Name:   u0.Name,
+		Status: u0Status,
+	}
+}
+
+func mapMainStatusToMainDTOStatus(s0 Status) DTOStatus {
+	switch s0 {
+	case StatusActive:
+		return DTOStatusActive
+	case StatusBanned:
+		return DTOStatusBanned
+	default:
+		return DTOStatusBanned
+	}
+}
+
+func ToDTO(u0 User) UserDTO {
+	u1 := mapMainUserToMainUserDTO(u0)
+	return u1
+}
+
+func ToDTOs(u0 []User) []UserDTO {
+	u1 := make([]UserDTO, len(u0))
+	for i, each := range u0 {
+		u1[i] = mapMainUserToMainUserDTO(each)
+	}
+	return u1
+}
+`
+
+func TestMapperFuncMode(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(funcModeProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Mode:    mapper.ModeFunc,
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(res, funcModeGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var funcPrototypeProgram = `
+package main
+
+//mapper:func
+var ToDTO func(User) UserDTO
+
+//mapper:func
+//mapper:field Name <- Email
+var ToContacts func([]*User) []UserDTO
+
+type User struct {
+	Name  string
+	Email string
+}
+
+type UserDTO struct {
+	Name string
+}
+`
+
+var funcPrototypeGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+func mapToContacts(u0 User) UserDTO {
+	return UserDTO{Name: u0.Email}
+}
+
+func mapMainUserToMainUserDTO(u0 User) UserDTO {
+	return UserDTO{Name: u0.Name}
+}
+
+func init() {
+	ToContacts = func(u0 []*User) []UserDTO {
+		u1 := make([]UserDTO, 0, len(u0))
+		for _, each := range u0 {
+			if each == nil {
+				continue
+			}
+			tmp := mapToContacts(*each)
+			u1 = append(u1, tmp)
+		}
+		return u1
+	}
+}
+
+func init() {
+	ToDTO = func(u0 User) UserDTO {
+		u1 := mapMainUserToMainUserDTO(u0)
+		return u1
+	}
+}
+`
+
+func TestMapperFuncPrototype(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(funcPrototypeProgram)
+	T, ok := mapper.NewPrototypes(pkg, mapper.NewDirectives(syntax))
+	if !ok {
+		t.Fatal("expected prototypes")
+	}
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Type:      T,
+				Prototype: true,
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(res, funcPrototypeGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var registerProgram = `
+package main
+
+type Mapper interface {
+	Map(A) B
+	MapAll([]A) []B
+	MapC(C) (*D, error)
+}
+
+type A struct {
+	Name string
+}
+
+type B struct {
+	Name string
+}
+
+type C struct {
+	age string
+}
+
+func (c C) Age() (int, error) {
+	return len(c.age), nil
+}
+
+type D struct {
+	Age int
+}
+`
+
+var registerGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapMainAToMainB(a0 A) B {
+	return B{Name: a0.Name}
+}
+
+func (m *Mapper) mapMainCToMainD(c0 C) (D, error) {
+	c0Age, err := c0.Age()
+	if err != nil {
+		return D{}, err
+	}
+	return D{Age: c0Age}, nil
+}
+
+func (m *Mapper) Map(a0 A) B {
+	a1 := m.mapMainAToMainB(a0)
+	return a1
+}
+
+func (m *Mapper) MapAll(a0 []A) []B {
+	a1 := make([]B, len(a0))
+	for i, each := range a0 {
+		a1[i] = m.mapMainAToMainB(each)
+	}
+	return a1
+}
+
+func (m *Mapper) MapC(c0 C) (*D, error) {
+	c1, err := m.mapMainCToMainD(c0)
+	if err != nil {
+		return nil, err
+	}
+	c2 := &c1
+	return c2, nil
+}
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+import mapper "github.com/alextanhongpin/mapper"
+
+// RegisterMapper registers the mappers of m for mapper.Map.
+func RegisterMapper(m *Mapper) {
+	mapper.Register(func(a0 A) (B, error) {
+		return m.mapMainAToMainB(a0), nil
+	})
+	mapper.Register(m.mapMainCToMainD)
+}
+`
+
+func TestMapperRegister(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(registerProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:      pkg,
+		Syntax:   syntax,
+		PkgName:  pkg.Name(),
+		PkgPath:  pkg.Path(),
+		Register: true,
+		DryRun:   true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(res, registerGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var suggestProgram = `
+package main
+
+type Cents int64
+
+type User struct {
+	ID           int
+	Name         string
+	EmailAddress string ` + "`json:\"email\"`" + `
+	Price        Cents
+	Discount     Cents
+	Password     string
+}
+
+func (u User) FullName() string {
+	return u.Name
+}
+
+type UserDTO struct {
+	ID       string
+	Nme      string
+	Email    string ` + "`json:\"email,omitempty\"`" + `
+	FullName string
+	Price    float64
+	Discount float64
+	Role     string
+	Secret   string ` + "`map:\"Password\"`" + `
+}
+
+func CentsToDollars(c Cents) float64 {
+	return float64(c) / 100
+}
+
+func CentsToFloat(c Cents) float64 {
+	return float64(c)
+}
+`
+
+var suggestStruct = "type UserDTO struct {\n" +
+	"\tID       string // TODO: no func found for int to string\n" +
+	"\tNme      string `map:\"Name\"`                                // from Name, edit distance 1\n" +
+	"\tEmail    string `json:\"email,omitempty\" map:\"EmailAddress\"` // from EmailAddress, same tag name \"email\"\n" +
+	"\tFullName string\n" +
+	"\tPrice    float64 `map:\",CentsToDollars\"` // candidates: CentsToDollars, CentsToFloat\n" +
+	"\tDiscount float64 `map:\",CentsToDollars\"` // candidates: CentsToDollars, CentsToFloat\n" +
+	"\tRole     string  // TODO: no source found\n" +
+	"\tSecret   string  `map:\"Password\"` // already tagged\n" +
+	"}\n"
+
+func TestSuggest(t *testing.T) {
+	pkg := loader.LoadPackageString(suggestProgram)
+	from := pkg.Scope().Lookup("User").Type().(*types.Named)
+	to := pkg.Scope().Lookup("UserDTO").Type().(*types.Named)
+
+	s, err := internal.Suggest(from, to, internal.NewConverters(), pkg.Scope())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(s.Struct(), suggestStruct); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var reportProgram = `
+package main
+
+type Mapper interface {
+	ToB(A) B
+	ToBs([]A) []B
+	ToD(C) D
+}
+
+type A struct {
+	ID     int
+	Name   string
+	Secret string
+	C      C
+}
+
+func (a A) FullName() string {
+	return a.Name
+}
+
+type B struct {
+	ID       string ` + "`map:\",IntToString\"`" + `
+	Nickname string ` + "`map:\"Name\"`" + `
+	FullName string
+	D        D      ` + "`map:\"C\"`" + `
+	Password string ` + "`map:\"-\"`" + `
+}
+
+type C struct {
+	Value int
+}
+
+type D struct {
+	Value int
+}
+
+func IntToString(i int) string {
+	return ""
+}
+`
+
+var reportMarkdown = "# Mapper\n" +
+	"\n" +
+	"## ToB\n" +
+	"\n" +
+	"`main.A` to `main.B`, mapped by `mapMainAToMainB`.\n" +
+	"\n" +
+	"| Field | Source | Kind | Chain |\n" +
+	"| --- | --- | --- | --- |\n" +
+	"| D | `C` | mapper | `mapMainCToMainD` |\n" +
+	"| FullName | `FullName()` | method |  |\n" +
+	"| ID | `ID` | tag | `IntToString` |\n" +
+	"| Nickname | `Name` | field |  |\n" +
+	"| Password |  | ignored |  |\n" +
+	"\n" +
+	"Unread source fields: `Secret`\n" +
+	"\n" +
+	"## ToBs\n" +
+	"\n" +
+	"`[]main.A` to `[]main.B`, mapped by `mapMainAToMainB`.\n" +
+	"\n" +
+	"| Field | Source | Kind | Chain |\n" +
+	"| --- | --- | --- | --- |\n" +
+	"| D | `C` | mapper | `mapMainCToMainD` |\n" +
+	"| FullName | `FullName()` | method |  |\n" +
+	"| ID | `ID` | tag | `IntToString` |\n" +
+	"| Nickname | `Name` | field |  |\n" +
+	"| Password |  | ignored |  |\n" +
+	"\n" +
+	"Unread source fields: `Secret`\n" +
+	"\n" +
+	"## ToD\n" +
+	"\n" +
+	"`main.C` to `main.D`, mapped by `mapMainCToMainD`.\n" +
+	"\n" +
+	"| Field | Source | Kind | Chain |\n" +
+	"| --- | --- | --- | --- |\n" +
+	"| Value | `Value` | field |  |\n"
+
+func TestMapperReport(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(reportProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Report:  "md",
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The report is rendered after the generated code.
+	res = res[strings.Index(res, "# Mapper"):]
+	if diff := cmp.Diff(res, reportMarkdown); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var graphProgram = `
+package main
+
+type Mapper interface {
+	ToB(A) B
+	ToD(C) D
+}
+
+type A struct {
+	ID       int
+	Children []A
+	C        C
+}
+
+type B struct {
+	ID       string ` + "`map:\",IntToString\"`" + `
+	Children []B
+	D        D ` + "`map:\"C\"`" + `
+}
+
+type C struct {
+	Value int
+}
+
+type D struct {
+	Value int
+}
+
+func IntToString(i int) string {
+	return ""
+}
+`
+
+var graphDOT = `digraph "Mapper" {
+	"IntToString" [shape=note];
+	"ToB" [shape=box];
+	"ToD" [shape=box];
+	"mapMainAToMainB" [shape=ellipse];
+	"mapMainCToMainD" [shape=ellipse];
+	"ToB" -> "mapMainAToMainB";
+	"ToD" -> "mapMainCToMainD";
+	"mapMainAToMainB" -> "IntToString" [label=tag];
+	"mapMainAToMainB" -> "mapMainAToMainB" [label=mapper, color=red];
+	"mapMainAToMainB" -> "mapMainCToMainD" [label=mapper];
+}
+`
+
+func TestMapperGraph(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(graphProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Graph:   "dot",
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The graph is rendered after the generated code.
+	res = res[strings.Index(res, "digraph"):]
+	if diff := cmp.Diff(res, graphDOT); diff != "" {
+		t.Fatal(diff)
+	}
+
+	graph := gen.graph.Build()
+	if diff := cmp.Diff([][]string{{"mapMainAToMainB"}}, graph.Cycles); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]string{"ToB"}, graph.Unused); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var testProgram = `
+package main
+
+type Mapper interface {
+	ToB(A) B
+	ToBs([]A) []B
+	ToC(A) (C, error)
+}
+
+type A struct {
+	Age  string
+	ID   int
+	Name string
+}
+
+type B struct {
+	ID       string ` + "`map:\",IntToString\"`" + `
+	Nickname string ` + "`map:\"Name\"`" + `
+}
+
+type C struct {
+	Age int ` + "`map:\",ParseAge\"`" + `
+}
+
+func IntToString(i int) string {
+	return ""
+}
+
+func ParseAge(s string) (int, error) {
+	return 0, nil
+}
+`
+
+var testGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapperToB(t *testing.T) {
+	m := NewMapperImpl()
+	a := A{
+		Age:  "1",
+		ID:   2,
+		Name: "3",
+	}
+	b := m.ToB(a)
+	if got, want := b.ID, IntToString(a.ID); !reflect.DeepEqual(got, want) {
+		t.Errorf("ID: got %v, want %v", got, want)
+	}
+	if got, want := b.Nickname, a.Name; !reflect.DeepEqual(got, want) {
+		t.Errorf("Nickname: got %v, want %v", got, want)
+	}
+}
+
+func TestMapperToBs(t *testing.T) {
+	m := NewMapperImpl()
+	a := []A{{
+		Age:  "1",
+		ID:   2,
+		Name: "3",
+	}}
+	b := m.ToBs(a)
+	if len(b) != 1 {
+		t.Fatalf("got %d elements, want 1", len(b))
+	}
+	if got, want := b[0].ID, IntToString(a[0].ID); !reflect.DeepEqual(got, want) {
+		t.Errorf("ID: got %v, want %v", got, want)
+	}
+	if got, want := b[0].Nickname, a[0].Name; !reflect.DeepEqual(got, want) {
+		t.Errorf("Nickname: got %v, want %v", got, want)
+	}
+}
+
+func TestMapperToC(t *testing.T) {
+	m := NewMapperImpl()
+	a := A{
+		Age:  "1",
+		ID:   2,
+		Name: "3",
+	}
+	b, err := m.ToC(a)
+	if err != nil {
+		t.Fatalf("cannot map the generated source: %v", err)
+	}
+	if want, err := ParseAge(a.Age); err != nil {
+		t.Errorf("Age: %v", err)
+	} else if got := b.Age; !reflect.DeepEqual(got, want) {
+		t.Errorf("Age: got %v, want %v", got, want)
+	}
+}
+`
+
+func TestMapperTest(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(testProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Suffix:  "Impl",
+		Test:    true,
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeCheck(t, testProgram, res)
+
+	// The tests are rendered after the generated code.
+	res = res[strings.LastIndex(res, "// Code generated"):]
+	if diff := cmp.Diff(res, testGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var fuzzProgram = `
+package main
+
+type Mapper interface {
+	ToB(A) (B, error)
+	ToC(A) C
+}
+
+type Kind int
+
+type A struct {
+	ID   string
+	Age  *int
+	Kind Kind
+	Tags []string
+}
+
+type B struct {
+	ID   int ` + "`map:\",ParseID\"`" + `
+	Age  *int
+	Kind Kind
+	Tags []string
+}
+
+type C struct {
+	Kind Kind
+}
+
+func ParseID(s string) (int, error) {
+	return 0, nil
+}
+`
+
+var fuzzGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+import "testing"
+
+func FuzzMapperToB(f *testing.F) {
+	m := NewMapper()
+	f.Add(int(1), true, "2", int(3), "4")
+	f.Fuzz(func(t *testing.T, inAge int, hasAge bool, inID string, inKind int, inTags string) {
+		a := A{
+			ID:   inID,
+			Kind: Kind(inKind),
+			Tags: []string{inTags},
+		}
+		if hasAge {
+			a.Age = &inAge
+		}
+		_, _ = m.ToB(a)
+	})
+}
+`
+
+func TestMapperFuzz(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(fuzzProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Fuzz:    true,
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the methods that return error are fuzzed.
+	res = res[strings.LastIndex(res, "// Code generated"):]
+	if diff := cmp.Diff(res, fuzzGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var roundTripProgram = `
+package main
+
+type Mapper interface {
+	//mapper:ignore Secret
+	ToB(A) B
+	ToA(B) A
+}
+
+type A struct {
+	ID     int
+	Name   string
+	Secret string
+}
+
+type B struct {
+	ID       int
+	Nickname string ` + "`map:\"Name\"`" + `
+	Secret   string
+}
+`
+
+var roundTripGenerated = `func TestMapperRoundTripToB(t *testing.T) {
+	m := NewMapper()
+	roundTrip := func(inID int, inName string) bool {
+		a := A{
+			ID:   inID,
+			Name: inName,
+		}
+		b := m.ToB(a)
+		got := m.ToA(b)
+		return reflect.DeepEqual(got.ID, a.ID) &&
+			reflect.DeepEqual(got.Name, a.Name)
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+`
+
+func TestMapperRoundTrip(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(roundTripProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Test:    true,
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The secret is ignored by ToB, and not checked.
+	start := strings.Index(res, "func TestMapperRoundTripToB")
+	if start == -1 {
+		t.Fatalf("missing TestMapperRoundTripToB:\n%s", res)
+	}
+	res = res[start:]
+	res = res[:strings.Index(res, "\n}\n")+3]
+	if diff := cmp.Diff(res, roundTripGenerated); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+var roundTripOneWayProgram = `
+package main
+
+import "strings"
+
+type Mapper interface {
+	//mapper:field Name using ToUpper
+	ToB(A) B
+	ToA(B) A
+}
+
+type A struct {
+	ID   int
+	Name string
+}
+
+type B struct {
+	ID   int
+	Name string
+}
+
+func ToUpper(s string) string {
+	return strings.ToUpper(s)
+}
+`
+
+func TestMapperRoundTripOneWay(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(roundTripOneWayProgram)
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Test:    true,
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: pkg.Scope().Lookup("Mapper").Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The name is transformed one way and copied back, which does not round
+	// trip.
+	start := strings.Index(res, "func TestMapperRoundTripToB")
+	if start == -1 {
+		t.Fatalf("missing TestMapperRoundTripToB:\n%s", res)
+	}
+	res = res[start:]
+	res = res[:strings.Index(res, "\n}\n")+3]
+	if !strings.Contains(res, "reflect.DeepEqual(got.ID, a.ID)") {
+		t.Fatalf("want ID to round trip, got:\n%s", res)
+	}
+	if strings.Contains(res, "Name") {
+		t.Fatalf("want Name to be skipped, got:\n%s", res)
+	}
+}
+
+var roundTripConvertedProgram = `
+package main
+
+import "strconv"
+
+type Mapper interface {
+	ToB(A) (B, error)
+	ToA(B) (A, error)
+}
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusBanned
+)
+
+type APIStatus int
+
+const (
+	APIStatusActive APIStatus = iota
+	APIStatusBanned
+)
+
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleGuest Role = "guest"
+)
+
+type APIRole string
+
+const (
+	APIRoleAdmin APIRole = "ADMIN"
+	APIRoleGuest APIRole = "GUEST" //mapper:enum default
+)
+
+//mapper:converter
+func IntToString(i int) string {
+	return strconv.Itoa(i)
+}
+
+//mapper:converter
+func StringToInt(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+type A struct {
+	ID     int
+	Status Status
+	Role   Role
+}
+
+type B struct {
+	ID     string
+	Status APIStatus
+	Role   APIRole
+}
+`
+
+func TestMapperRoundTripConverted(t *testing.T) {
+	pkg, syntax := loader.LoadPackageStringSyntax(roundTripConvertedProgram)
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		Test:    true,
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: pkg.Scope().Lookup("Mapper").Type(),
+			},
+		},
+	})
+
+	res, err := gen.GenerateString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The converters are not known to be inverse, e.g. "+1" is mapped back to
+	// "1", and the unknown roles are mapped to the default.
+	start := strings.Index(res, "func TestMapperRoundTripToB")
+	if start == -1 {
+		t.Fatalf("missing TestMapperRoundTripToB:\n%s", res)
+	}
+	res = res[start:]
+	res = res[:strings.Index(res, "\n}\n")+3]
+	if !strings.Contains(res, "roundTrip := func(inStatus Status) bool {") {
+		t.Fatalf("want only Status to round trip, got:\n%s", res)
+	}
+}
+
+var strictProgram = `
+package main
+
+//mapper:strict
+type Mapper interface {
+	//mapper:drop Dropped
+	ToB(A) B
+	//mapper:drop Dropped
+	ToBs([]A) []B
+}
+
+type A struct {
+	ID       int
+	Name     string
+	Secret   string
+	Internal string ` + "`map:\"-\"`" + `
+	Dropped  string
+	private  string
+}
+
+type B struct {
+	ID   int
+	Name string
+}
+`
+
+func TestMapperStrict(t *testing.T) {
+	pkg, syntax, fset := loader.LoadPackageStringFileSet(strictProgram)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		Fset:    fset,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+
+		expected := "interface Mapper does not read every source field in strict mode\n" +
+			"detail:\n" +
+			"- hello.go:15:2: field \"Secret\" of main.A is not read by ToB\n" +
+			"- hello.go:15:2: field \"Secret\" of main.A is not read by ToBs\n" +
+			"help: map the fields, tag them with `map:\"-\"`, or add //mapper:drop Field to the method"
+		if diff := cmp.Diff(expected, err.Error()); diff != "" {
+			t.Fatal(diff)
+		}
+	}()
+	_, _ = gen.GenerateString()
+}
+
+func TestMapperStrictDropped(t *testing.T) {
+	program := strings.ReplaceAll(strictProgram, "//mapper:drop Dropped", "//mapper:drop Dropped Secret")
+	pkg, syntax := loader.LoadPackageStringSyntax(program)
+	obj := pkg.Scope().Lookup("Mapper")
+	gen := NewGenerator(mapper.Option{
+		Pkg:     pkg,
+		Syntax:  syntax,
+		PkgName: pkg.Name(),
+		PkgPath: pkg.Path(),
+		DryRun:  true,
+		Items: []mapper.OptionItem{
+			{
+				Name: "Mapper",
+				Type: obj.Type(),
+			},
+		},
+	})
+	if _, err := gen.GenerateString(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var lenientProgram = `
+package main
+
+type Mapper interface {
+	//mapper:lenient
+	ToB(A) B
+}
+
+type A struct {
+	ID int
+}
+
+type B struct {
+	ID      int
+	Name    string
+	Version int
+}
+`
+
+var lenientGenerated = `// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+
+package main
+
+var _ Mapper = (*Mapper)(nil)
+
+type Mapper struct{}
+
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+func (m *Mapper) mapToB(a0 A) B {
+	return B{ID: a0.ID}
+}
+
+func (m *Mapper) ToB(a0 A) B {
+	a1 := m.mapToB(a0)
+	return a1
+}
+`
+
+func TestMapperLenient(t *testing.T) {
+	for _, program := range []string{
+		lenientProgram,
+		// The interface is lenient.
+		strings.Replace(strings.Replace(lenientProgram, "\t//mapper:lenient\n", "", 1), "type Mapper interface", "//mapper:lenient\ntype Mapper interface", 1),
+	} {
+		pkg, syntax := loader.LoadPackageStringSyntax(program)
+		obj := pkg.Scope().Lookup("Mapper")
+		gen := NewGenerator(mapper.Option{
+			Pkg:     pkg,
+			Syntax:  syntax,
+			PkgName: pkg.Name(),
+			PkgPath: pkg.Path(),
+			DryRun:  true,
+			Items: []mapper.OptionItem{
+				{
+					Name: "Mapper",
+					Type: obj.Type(),
+				},
+			},
+		})
+
+		res, err := gen.GenerateString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(res, lenientGenerated); diff != "" {
+			t.Fatal(diff)
+		}
+		if diff := cmp.Diff([]string{"ToB leaves the fields Name, Version of main.B at their zero value"}, gen.warnings); diff != "" {
+			t.Fatal(diff)
+		}
+	}
+}
+
+var lenientNestedProgram = `
+package main
+
+type Mapper interface {
+	//mapper:lenient
+	ToAddressDTO(Address) AddressDTO
+	ToUserDTO(User) UserDTO
+}
+
+type Address struct {
+	City string
+}
+
+type AddressDTO struct {
+	City    string
+	Country string
+}
+
+type User struct {
+	Address Address
+}
+
+type UserDTO struct {
+	Address AddressDTO
+}
+`
+
+func TestMapperLenientNested(t *testing.T) {
+	// The nested address is mapped by the lenient method.
+	res := generateString(t, lenientNestedProgram, "Mapper")
+	for _, want := range []string{
+		"func (m *Mapper) mapToAddressDTO(a0 Address) AddressDTO {\n\treturn AddressDTO{City: a0.City}\n}",
+		"u0Address := m.mapToAddressDTO(u0.Address)",
+	} {
+		if !strings.Contains(res, want) {
+			t.Errorf("missing %q in:\n%s", want, res)
+		}
+	}
+}
+
+var multipleProgram = `
+package main
+
+type UserMapper interface {
+	ToUserDTO(User) UserDTO
+	ToAddressDTO(Address) AddressDTO
+}
+
+type OrderMapper interface {
+	ToOrderDTO(Order) OrderDTO
+	ToAddressDTO(Address) AddressDTO
+}
+
+type Address struct {
+	City string
+}
+
+type AddressDTO struct {
+	City string
+}
+
+type User struct {
+	Name    string
+	Address Address
+}
+
+type UserDTO struct {
+	Name    string
+	Address AddressDTO
+}
+
+type Order struct {
+	ID       int
+	Shipping Address
+}
+
+type OrderDTO struct {
+	ID       int
+	Shipping AddressDTO
+}
+
+type Formatter struct{}
+
+func (Formatter) Format(s string) string {
+	return s
+}
+`
+
+func TestMapperMultiple(t *testing.T) {
+	load := func(program string) (*types.Package, []*ast.File, []mapper.OptionItem) {
+		pkg, syntax := loader.LoadPackageStringSyntax(program)
+		var items []mapper.OptionItem
+		for _, name := range []string{"OrderMapper", "UserMapper"} {
+			items = append(items, mapper.OptionItem{
+				Name: name,
+				Type: pkg.Scope().Lookup(name).Type(),
+				Path: strings.ToLower(name) + "_gen.go",
+			})
+		}
+		return pkg, syntax, items
+	}
+
+	t.Run("isolated", func(t *testing.T) {
+		// Only UserMapper depends on the Formatter.
+		program := strings.Replace(multipleProgram, "type UserDTO struct {\n\tName    string", "type UserDTO struct {\n\tName    string `map:\",Formatter.Format\"`", 1)
+		pkg, syntax, items := load(program)
+		gen := NewGenerator(mapper.Option{
+			Pkg:     pkg,
+			Syntax:  syntax,
+			PkgName: pkg.Name(),
+			PkgPath: pkg.Path(),
+			Suffix:  "Impl",
+			DryRun:  true,
+			Items:   items,
+		})
+		res, err := gen.GenerateString()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Each interface is rendered into its own file, with its own
+		// dependencies and private mappers.
+		if got := strings.Count(res, "// Code generated"); got != 2 {
+			t.Fatalf("got %d files, want 2", got)
+		}
+		for _, want := range []string{
+			"type OrderMapperImpl struct{}",
+			"func (o *OrderMapperImpl) mapMainAddressToMainAddressDTO(a0 Address) AddressDTO",
+			"type UserMapperImpl struct {\n\tformatter *Formatter\n}",
+			"func (u *UserMapperImpl) mapMainAddressToMainAddressDTO(a0 Address) AddressDTO",
+		} {
+			if !strings.Contains(res, want) {
+				t.Errorf("missing %q in:\n%s", want, res)
+			}
+		}
+	})
+
+	for _, combine := range []bool{true, false} {
+		t.Run(fmt.Sprintf("func mode combine=%t", combine), func(t *testing.T) {
+			pkg, syntax, items := load(multipleProgram)
+			gen := NewGenerator(mapper.Option{
+				Pkg:     pkg,
+				Syntax:  syntax,
+				PkgName: pkg.Name(),
+				PkgPath: pkg.Path(),
+				Mode:    mapper.ModeFunc,
+				Combine: combine,
+				DryRun:  true,
+				Items:   items,
+			})
+			res, err := gen.GenerateString()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The shared private mapper, and the identical ToAddressDTO of
+			// both interfaces, are declared once in the package.
+			typeCheck(t, multipleProgram, res)
+		})
+	}
+
+	t.Run("func mode conflict", func(t *testing.T) {
+		program := strings.Replace(multipleProgram, "type OrderMapper interface {\n\tToOrderDTO(Order) OrderDTO\n\tToAddressDTO(Address) AddressDTO", "type OrderMapper interface {\n\tToOrderDTO(Order) OrderDTO\n\t//mapper:ignore City\n\tToAddressDTO(Address) AddressDTO", 1)
+		pkg, syntax, items := load(program)
+		gen := NewGenerator(mapper.Option{
+			Pkg:     pkg,
+			Syntax:  syntax,
+			PkgName: pkg.Name(),
+			PkgPath: pkg.Path(),
+			Mode:    mapper.ModeFunc,
+			DryRun:  true,
+			Items:   items,
+		})
+
+		defer func() {
+			err, _ := recover().(error)
+			if err == nil || !strings.Contains(err.Error(), "func ToAddressDTO is declared by interfaces OrderMapper and UserMapper with different mappings") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}()
+		_, _ = gen.GenerateString()
+	})
+}
+
+// typeCheck fails the test if the generated files, rendered one after another
+// in dry run, do not compile with the program.
+func typeCheck(t *testing.T, program, generated string) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	sources := []string{program}
+	for _, src := range strings.Split(generated, "// Code generated")[1:] {
+		sources = append(sources, "// Code generated"+src)
+	}
+
+	var files []*ast.File
+	for i, src := range sources {
+		f, err := parser.ParseFile(fset, fmt.Sprintf("file%d.go", i), src, 0)
+		if err != nil {
+			t.Fatalf("%v in:\n%s", err, src)
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check("main", fset, files, nil); err != nil {
+		t.Fatalf("%v in:\n%s", err, generated)
+	}
+}
+
+func TestMapperInputHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapper_gen.go")
+	generate := func(program string, force bool) string {
+		pkg := loader.LoadPackageString(program)
+		gen := NewGenerator(mapper.Option{
+			Pkg:     pkg,
+			PkgName: pkg.Name(),
+			PkgPath: pkg.Path(),
+			Prune:   true,
+			Force:   force,
+			Items: []mapper.OptionItem{{
+				Name: "Mapper",
+				Type: pkg.Scope().Lookup("Mapper").Type(),
+				Path: path,
+			}},
+		})
+		if err := gen.Generate(); err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	// edit marks the generated file, which is kept if it is skipped.
+	edit := func(content string) {
+		if err := os.WriteFile(path, []byte(content+"// edited\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res := generate(program, false)
+	if !strings.Contains(res, "// "+internal.InputHashPrefix+"sha256:") {
+		t.Fatalf("missing input hash in:\n%s", res)
+	}
+
+	edit(res)
+	if res := generate(program, false); !strings.HasSuffix(res, "// edited\n") {
+		t.Fatalf("want unchanged inputs to be skipped, got:\n%s", res)
+	}
+	if res := generate(program, true); strings.HasSuffix(res, "// edited\n") {
+		t.Fatalf("want -force to generate, got:\n%s", res)
+	}
+
+	// Changing a tag of the involved types changes the hash.
+	edit(res)
+	tagged := strings.Replace(program, "type B struct {\n\tName string", "type B struct {\n\tName string `map:\"Name\"`", 1)
+	if res := generate(tagged, false); strings.HasSuffix(res, "// edited\n") || res == generate(program, true) {
+		t.Fatalf("want changed inputs to be generated, got:\n%s", res)
+	}
+}
+
+func TestMapperInputHashCompanion(t *testing.T) {
+	// A deleted test file is generated, although the inputs are unchanged.
+	path := filepath.Join(t.TempDir(), "mapper_gen.go")
+	pkg := loader.LoadPackageString(program)
+	generate := func() {
+		gen := NewGenerator(mapper.Option{
+			Pkg:     pkg,
+			PkgName: pkg.Name(),
+			PkgPath: pkg.Path(),
+			Test:    true,
+			Items: []mapper.OptionItem{{
+				Name: "Mapper",
+				Type: pkg.Scope().Lookup("Mapper").Type(),
+				Path: path,
+			}},
+		})
+		if err := gen.Generate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	generate()
+	if err := os.Remove(testPath(path)); err != nil {
+		t.Fatal(err)
+	}
+	generate()
+	if _, err := os.Stat(testPath(path)); err != nil {
+		t.Fatalf("want the deleted test file to be generated: %v", err)
+	}
+}
+
+var inputHashDirectiveProgram = `
+package main
+
+type Mapper interface {
+	//mapper:field Name using Format
+	Map(A) (B, error)
+}
+
+type A struct {
+	Name string
+}
+
+type B struct {
+	Name string
+}
+
+func Format(s string) string {
+	return s
+}
+`
+
+func TestMapperInputHashDirective(t *testing.T) {
+	// The funcs of the directives are hashed by their signature.
+	hash := func(program string) string {
+		pkg, files := loader.LoadPackageStringSyntax(program)
+		opt := mapper.Option{
+			Pkg:     pkg,
+			PkgName: pkg.Name(),
+			PkgPath: pkg.Path(),
+			Syntax:  files,
+			Items: []mapper.OptionItem{{
+				Name: "Mapper",
+				Type: pkg.Scope().Lookup("Mapper").Type(),
+			}},
+		}
+		return NewGenerator(opt).inputHash(opt.Items)
+	}
+
+	changed := strings.Replace(inputHashDirectiveProgram, "func Format(s string) string {\n\treturn s", "func Format(s string) (string, error) {\n\treturn s, nil", 1)
+	if hash(inputHashDirectiveProgram) == hash(changed) {
+		t.Fatal("want the changed signature of the directive func to change the hash")
+	}
+}
+
+func TestMapperInputHashSharedFuncs(t *testing.T) {
+	// In func mode, the interfaces share mapMainAddressToMainAddressDTO,
+	// which is declared by the first file. Changing only the second interface
+	// must not redeclare it.
+	dir := t.TempDir()
+	generate := func(program string) {
+		pkg, syntax := loader.LoadPackageStringSyntax(program)
+		var items []mapper.OptionItem
+		for _, name := range []string{"OrderMapper", "UserMapper"} {
+			items = append(items, mapper.OptionItem{
+				Name: name,
+				Type: pkg.Scope().Lookup(name).Type(),
+				Path: filepath.Join(dir, strings.ToLower(name)+"_gen.go"),
+			})
+		}
+		gen := NewGenerator(mapper.Option{
+			Pkg:     pkg,
+			Syntax:  syntax,
+			PkgName: pkg.Name(),
+			PkgPath: pkg.Path(),
+			Mode:    mapper.ModeFunc,
+			Items:   items,
+		})
+		if err := gen.Generate(); err != nil {
+			t.Fatal(err)
+		}
+
+		var generated string
+		for _, item := range items {
+			b, err := os.ReadFile(item.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			generated += string(b)
+		}
+		typeCheck(t, program, generated)
+	}
+
+	generate(multipleProgram)
+	generate(strings.Replace(multipleProgram, "type UserDTO struct {\n\tName    string\n", "type UserDTO struct {\n", 1))
+}
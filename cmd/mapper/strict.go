@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/cmd/mapper/internal"
+)
+
+// checkStrict panics if the interface has the //mapper:strict directive, and
+// the methods do not read an exported source field that is neither tagged
+// `map:"-"`, nor dropped with //mapper:drop.
+func (g *Generator) checkStrict(opt mapper.OptionItem, methods map[string]*mapper.Func, keys []string) {
+	if opt.Prototype || !g.directives.Has(opt.Name, internal.StrictDirective) {
+		return
+	}
+
+	var unread []string
+	for i, report := range g.genReport(opt, methods, keys).Methods {
+		method := methods[keys[i]]
+		// The slices are mapped by the mapper of their elements, which must
+		// read the fields of the element.
+		from := method.From.Type
+		if slice, ok := from.Underlying().(*types.Slice); ok {
+			from = slice.Elem()
+		}
+		dropped := internal.NewDroppedFields(g.directives.Lookup(opt.Name+"."+method.Name, internal.DropDirective))
+		methodInfo, _ := g.interfaceVisitor.MethodInfo(method.Name)
+		sources := methodInfo.Param.StructFields()
+		for _, key := range report.Unread {
+			field := sources[key]
+			if !field.Exported || dropped[field.Name] || dropped[key] {
+				continue
+			}
+
+			// Output:
+			//
+			// main.go:12:2: field "Secret" of main.A is not read by ToB
+			msg := fmt.Sprintf("field %q of %s is not read by %s", field.Name, types.TypeString(from, (*types.Package).Name), method.Name)
+			if pos := internal.FieldPosition(g.opt.Fset, g.opt.Syntax, g.opt.PkgPath, from, field.Name); pos != "" {
+				msg = pos + ": " + msg
+			}
+			unread = append(unread, msg)
+		}
+	}
+	if len(unread) > 0 {
+		panic(fmt.Errorf("interface %s does not read every source field in strict mode\ndetail:\n- %s\nhelp: map the fields, tag them with `map:\"-\"`, or add //mapper:drop Field to the method",
+			opt.Name,
+			strings.Join(unread, "\n- "),
+		))
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/alextanhongpin/mapper/cmd/mapper/internal"
+	"github.com/alextanhongpin/mapper/loader"
+	"golang.org/x/tools/go/packages"
+)
+
+// Suggest prints the target struct with the proposed map tags, or the
+// interface method with the proposed directives, e.g.
+//
+//	mapper suggest -from github.com/your-org/yourpkg.User -to ./dto.User
+func Suggest(args []string) error {
+	fs := flag.NewFlagSet("suggest", flag.ExitOnError)
+	fromp := fs.String("from", "", "the source struct, e.g. github.com/your-org/yourpkg.User")
+	top := fs.String("to", "", "the target struct, e.g. github.com/your-org/yourpkg.UserDTO")
+	convertersp := fs.String("converters", "", "the comma-separated packages to load the //mapper:converter functions from")
+	methodp := fs.Bool("method", false, "prints the interface method with //mapper:field directives, for target structs that cannot be tagged")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *fromp == "" || *top == "" {
+		return fmt.Errorf("mapper: suggest requires -from and -to")
+	}
+
+	pkgs := make(map[string]*packages.Package)
+	fromPkg, from, err := loadNamed(pkgs, *fromp)
+	if err != nil {
+		return err
+	}
+	toPkg, to, err := loadNamed(pkgs, *top)
+	if err != nil {
+		return err
+	}
+
+	// The converters are applied without tags, and are not proposed.
+	converters := internal.DefaultConverters().Extend()
+	for _, pkg := range pkgs {
+		converters.AddAnnotated(pkg.Types, pkg.Syntax)
+	}
+	if *convertersp != "" {
+		for _, pkgPath := range strings.Split(*convertersp, ",") {
+			if err := converters.LoadAnnotated(pkgPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	scopes := []*types.Scope{fromPkg.Types.Scope()}
+	if toPkg != fromPkg {
+		scopes = append(scopes, toPkg.Types.Scope())
+	}
+	s, err := internal.Suggest(from, to, converters, scopes...)
+	if err != nil {
+		return fmt.Errorf("mapper: %w", err)
+	}
+	if *methodp {
+		fmt.Print(s.Method())
+	} else {
+		fmt.Print(s.Struct())
+	}
+	return nil
+}
+
+// loadNamed loads the type named by the package path and the type name, e.g.
+// github.com/your-org/yourpkg.User, or User in the current package.
+func loadNamed(pkgs map[string]*packages.Package, name string) (*packages.Package, *types.Named, error) {
+	pkgPath, typeName := ".", name
+	if i := strings.LastIndex(name, "."); i > 0 {
+		pkgPath, typeName = name[:i], name[i+1:]
+	}
+
+	pkg, ok := pkgs[pkgPath]
+	if !ok {
+		pkg = loader.LoadPackage(pkgPath)
+		pkgs[pkgPath] = pkg
+	}
+	obj := pkg.Types.Scope().Lookup(typeName)
+	if obj == nil {
+		return nil, nil, fmt.Errorf("mapper: type %s not found in %q", typeName, pkg.PkgPath)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, nil, fmt.Errorf("mapper: %s is not a named type", obj)
+	}
+	return pkg, named, nil
+}
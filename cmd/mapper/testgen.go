@@ -0,0 +1,493 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/cmd/mapper/internal"
+	"github.com/dave/jennifer/jen"
+	. "github.com/dave/jennifer/jen"
+)
+
+// testPath returns the path of the generated tests, e.g. mapper_gen_test.go
+// for mapper_gen.go.
+func testPath(out string) string {
+	return strings.TrimSuffix(out, ".go") + "_test.go"
+}
+
+// genTests generates the tests of the public methods with -test, and the fuzz
+// targets of the public methods that return error with -fuzz. The methods that
+// have an inverse, e.g. ToB(A) B and ToA(B) A, are also tested round trip. The
+// slice methods are only tested with one element.
+func (g *Generator) genTests(f *jen.File, opt mapper.OptionItem, methods map[string]*mapper.Func, keys []string) {
+	for _, key := range keys {
+		fn := methods[key]
+		isSlice := mapper.IsSlice(fn.From.Type)
+		if isSlice != mapper.IsSlice(fn.To.Type) {
+			continue
+		}
+		if g.opt.Test {
+			f.Func().Id("Test" + opt.Name + fn.Name).Params(Id("t").Op("*").Qual("testing", "T")).BlockFunc(func(group *Group) {
+				g.genTestBody(group, opt, fn)
+			}).Line()
+
+			if inverse, ok := inverseMethod(fn, methods, keys); ok && !isSlice {
+				if fields := g.roundTripFields(opt, fn, inverse); len(fields) > 0 {
+					f.Func().Id("Test" + opt.Name + "RoundTrip" + fn.Name).Params(Id("t").Op("*").Qual("testing", "T")).BlockFunc(func(group *Group) {
+						g.genRoundTripBody(group, opt, fn, inverse, fields)
+					}).Line()
+				}
+			}
+		}
+		if g.opt.Fuzz && fn.Error && !isSlice {
+			f.Func().Id("Fuzz" + opt.Name + fn.Name).Params(Id("f").Op("*").Qual("testing", "F")).BlockFunc(func(group *Group) {
+				g.genFuzzBody(group, opt, fn)
+			}).Line()
+		}
+	}
+}
+
+// genTestMapper generates the construction of the mapper, and returns false if
+// the mapper cannot be constructed, in which case the test t is skipped.
+func (g *Generator) genTestMapper(group *Group, t string, opt mapper.OptionItem) bool {
+	if g.isFuncMode(opt) {
+		return true
+	}
+
+	// The interface dependencies cannot be constructed.
+	var args []Code
+	for _, name := range g.dependenciesKeys() {
+		dep := g.dependencies[name]
+		if !mapper.IsStruct(dep) {
+			group.Id(t).Dot("Skip").Call(Lit(fmt.Sprintf("New%s requires the dependency %s", g.genTypeName(opt), types.TypeString(dep, (*types.Package).Name))))
+			return false
+		}
+		args = append(args, Op("&").Add(internal.GenTypeName(dep)).Values())
+	}
+	group.Id("m").Op(":=").Id("New" + g.genTypeName(opt)).Call(args...)
+	return true
+}
+
+// testCall returns the public method of the mapper constructed by
+// genTestMapper, or the func in func mode.
+func (g *Generator) testCall(opt mapper.OptionItem, fn *mapper.Func) *Statement {
+	if g.isFuncMode(opt) {
+		return Id(fn.Name)
+	}
+	return Id("m").Dot(fn.Name)
+}
+
+// genTestBody generates the test, which maps a source with distinct non-zero
+// fields, and checks that the fields that are copied directly, renamed, or
+// transformed by a tag func land in the target fields. The slices are mapped
+// with one element.
+func (g *Generator) genTestBody(group *Group, opt mapper.OptionItem, fn *mapper.Func) {
+	/*
+		Output:
+
+		m := NewMapperImpl()
+		a := A{
+			ID:   1,
+			Name: "2",
+		}
+		b := m.ToB(a)
+		if got, want := b.ID, IntToString(a.ID); !reflect.DeepEqual(got, want) {
+			t.Errorf("ID: got %v, want %v", got, want)
+		}
+	*/
+	if !g.genTestMapper(group, "t", opt) {
+		return
+	}
+	call := g.testCall(opt, fn)
+
+	from := fn.From.Type
+	isSlice := mapper.IsSlice(from)
+	if isSlice {
+		from = from.(*types.Slice).Elem()
+	}
+	// The element of a and b that is checked.
+	elem := func(name string) *Statement {
+		if isSlice {
+			return Id(name).Index(Lit(0))
+		}
+		return Id(name)
+	}
+
+	var (
+		pkgPath, _    = g.opt.OutPkg()
+		methodInfo, _ = g.interfaceVisitor.MethodInfo(fn.Name)
+		sources       = mapper.NewStructFields(from)
+		populated     = make(map[string]bool)
+		values        = make(Dict)
+	)
+	for i, name := range generateSortedStructFields(sources) {
+		field := sources[name]
+		if !field.IsAccessibleFrom(pkgPath) {
+			continue
+		}
+		if value, ok := internal.TestValue(field.Type, i+1); ok {
+			values[Id(field.Name)] = value
+			populated[field.Name] = true
+		}
+	}
+	var src *Statement
+	switch {
+	case isSlice:
+		// The type of the element is elided, e.g. []A{{ID: 1}}.
+		src = internal.GenType(fn.From.Type).Values(Values(values))
+	case mapper.IsPointer(from):
+		src = Op("&").Add(internal.GenTypeName(from).Values(values))
+	default:
+		src = internal.GenTypeName(from).Values(values)
+	}
+	group.Id("a").Op(":=").Add(src)
+
+	if fn.Error {
+		// The generated strings are decimal, so that the tag funcs that return
+		// error accept them, e.g. strconv.Atoi.
+		group.List(Id("b"), Err()).Op(":=").Add(call).Call(Id("a"))
+		group.If(Err().Op("!=").Nil()).Block(
+			Id("t").Dot("Fatalf").Call(Lit("cannot map the generated source: %v"), Err()),
+		)
+	} else {
+		group.Id("b").Op(":=").Add(call).Call(Id("a"))
+	}
+	if isSlice {
+		group.If(Len(Id("b")).Op("!=").Lit(1)).Block(
+			Id("t").Dot("Fatalf").Call(Lit("got %d elements, want 1"), Len(Id("b"))),
+		)
+	}
+
+	reports := append([]*internal.FieldReport{}, g.fieldReports[g.mapperKey(fn, opt)]...)
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+	sourceMethods := mapper.NewNamedVisitor(from).Methods()
+	var checked int
+	for _, report := range reports {
+		rhs, ok := methodInfo.Result.FieldByName(report.Name)
+		if !ok || !rhs.IsAccessibleFrom(pkgPath) {
+			continue
+		}
+
+		var (
+			want  *Statement
+			wantE bool // The want is returned with error.
+		)
+		switch report.Kind {
+		case internal.SourceField:
+			// The source is exposed by the alias.
+			lhs, ok := methodInfo.Param.FieldByName(report.Source)
+			if ok && populated[lhs.Name] && mapper.IsIdentical(lhs.Type, rhs.Type) {
+				want = elem("a").Dot(lhs.Name)
+			}
+		case internal.SourceMethod:
+			method, ok := sourceMethods[strings.TrimSuffix(report.Source, "()")]
+			if ok && !method.Error && mapper.IsIdentical(method.To.Type, rhs.Type) {
+				want = elem("a").Dot(method.Name).Call()
+			}
+		case internal.SourceTag:
+			// Only the tag funcs of the source fields are called directly.
+			lhs, ok := methodInfo.Param.FieldByName(report.Source)
+			tag := methodInfo.Tag(rhs)
+			if !ok || !populated[lhs.Name] || len(report.Chain) != 1 || tag == nil || !tag.IsFunc() {
+				break
+			}
+			tagFn, ok := methodInfo.MapperByTag(tag.Tag)
+			if ok && mapper.IsIdentical(tagFn.From.Type, lhs.Type) && mapper.IsIdentical(tagFn.To.Type, rhs.Type) {
+				want = Qual(tagFn.PkgPath, tagFn.Name).Call(elem("a").Dot(lhs.Name))
+				wantE = tagFn.Error
+			}
+		}
+		if want == nil {
+			continue
+		}
+		checked++
+
+		if wantE {
+			// Output:
+			//
+			// if want, err := strconv.Atoi(a.Age); err != nil {
+			//	t.Errorf("Age: %v", err)
+			// } else if got := b.Age; !reflect.DeepEqual(got, want) {
+			//	t.Errorf("Age: got %v, want %v", got, want)
+			// }
+			group.If(
+				List(Id("want"), Err()).Op(":=").Add(want),
+				Err().Op("!=").Nil(),
+			).Block(
+				Id("t").Dot("Errorf").Call(Lit(rhs.Name+": %v"), Err()),
+			).Else().If(
+				Id("got").Op(":=").Add(elem("b")).Dot(rhs.Name),
+				Op("!").Qual("reflect", "DeepEqual").Call(Id("got"), Id("want")),
+			).Block(
+				Id("t").Dot("Errorf").Call(Lit(rhs.Name+": got %v, want %v"), Id("got"), Id("want")),
+			)
+			continue
+		}
+
+		// Output:
+		//
+		// if got, want := b.Name, a.Name; !reflect.DeepEqual(got, want) {
+		//	t.Errorf("Name: got %v, want %v", got, want)
+		// }
+		group.If(
+			List(Id("got"), Id("want")).Op(":=").List(elem("b").Dot(rhs.Name), want),
+			Op("!").Qual("reflect", "DeepEqual").Call(Id("got"), Id("want")),
+		).Block(
+			Id("t").Dot("Errorf").Call(Lit(rhs.Name+": got %v, want %v"), Id("got"), Id("want")),
+		)
+	}
+	if checked == 0 {
+		group.Id("_").Op("=").Id("b")
+	}
+}
+
+// genFuzzBody generates the fuzz target, which maps the source built from the
+// fuzz inputs, and fails if the mapper panics.
+func (g *Generator) genFuzzBody(group *Group, opt mapper.OptionItem, fn *mapper.Func) {
+	/*
+		Output:
+
+		m := NewMapperImpl()
+		f.Add(int(1), "2", true)
+		f.Fuzz(func(t *testing.T, inID int, inEmail string, hasEmail bool) {
+			a := User{
+				ID: inID,
+			}
+			if hasEmail {
+				a.Email = &inEmail
+			}
+			_, _ = m.ToAccount(a)
+		})
+	*/
+	if !g.genTestMapper(group, "f", opt) {
+		return
+	}
+	call := g.testCall(opt, fn)
+
+	var (
+		pkgPath, _ = g.opt.OutPkg()
+		sources    = mapper.NewStructFields(fn.From.Type)
+		params     = []Code{Id("t").Op("*").Qual("testing", "T")}
+		seeds      []Code
+		values     = make(Dict)
+		pointers   []Code
+	)
+	for i, name := range generateSortedStructFields(sources) {
+		field := sources[name]
+		if !field.IsAccessibleFrom(pkgPath) {
+			continue
+		}
+		input, ok := internal.NewFuzzInput(field.Type)
+		if !ok {
+			continue
+		}
+
+		in := Id("in" + field.Name)
+		params = append(params, Id("in"+field.Name).Add(input.Type()))
+		seeds = append(seeds, input.Seed(i+1))
+
+		switch {
+		case mapper.IsPointer(field.Type):
+			// Output:
+			//
+			// if hasEmail {
+			//	a.Email = &inEmail
+			// }
+			params = append(params, Id("has"+field.Name).Bool())
+			seeds = append(seeds, True())
+			pointers = append(pointers, If(Id("has"+field.Name)).Block(
+				Id("a").Dot(field.Name).Op("=").Add(input.Value(in)),
+			))
+		default:
+			values[Id(field.Name)] = input.Value(in)
+		}
+	}
+
+	src := internal.GenTypeName(fn.From.Type).Values(values)
+	if mapper.IsPointer(fn.From.Type) {
+		src = Op("&").Add(src)
+	}
+	group.Id("f").Dot("Add").Call(seeds...)
+	group.Id("f").Dot("Fuzz").Call(Func().Params(params...).BlockFunc(func(group *Group) {
+		group.Id("a").Op(":=").Add(src)
+		for _, stmt := range pointers {
+			group.Add(stmt)
+		}
+		// The mapper may return error for any input, but must not panic.
+		group.List(Id("_"), Id("_")).Op("=").Add(call).Call(Id("a"))
+	}))
+}
+
+// inverseMethod returns the method that maps the target of fn back to its
+// source, e.g. ToA(B) A for ToB(A) B.
+func inverseMethod(fn *mapper.Func, methods map[string]*mapper.Func, keys []string) (*mapper.Func, bool) {
+	for _, key := range keys {
+		other := methods[key]
+		if mapper.IsIdentical(other.From.Type, fn.To.Type) && mapper.IsIdentical(other.To.Type, fn.From.Type) {
+			return other, true
+		}
+	}
+	return nil, false
+}
+
+// roundTripFields returns the source fields of fn that are mapped back to
+// themselves by the inverse, sorted by name. A field round trips if it is
+// copied both ways, or mapped both ways by the names of the enum constants,
+// without a default constant, which the unknown values would be mapped to.
+// The fields that are ignored, mapped one way, or transformed by converters
+// or the funcs of the tags, are skipped, since they are not known to be
+// inverse, e.g. strconv.Itoa and strconv.Atoi map "+1" back to "1".
+func (g *Generator) roundTripFields(opt mapper.OptionItem, fn, inverse *mapper.Func) []mapper.StructField {
+	isRoundTrip := func(kind string) bool {
+		switch kind {
+		case internal.SourceField, internal.SourceEnum:
+			return true
+		default:
+			// The methods are read one way, and the nested structs are not
+			// populated.
+			return false
+		}
+	}
+
+	// The source of the report is the key, which may be the alias of the
+	// field, e.g. the map tag of the source field.
+	sourceField := func(fn *mapper.Func, report *internal.FieldReport) (string, bool) {
+		methodInfo, ok := g.interfaceVisitor.MethodInfo(fn.Name)
+		if !ok || !isRoundTrip(report.Kind) {
+			return "", false
+		}
+		lhs, ok := methodInfo.Param.FieldByName(report.Source)
+		return lhs.Name, ok
+	}
+
+	// The source of each target field, e.g. Nickname <- Name, and the kind
+	// of the mapping.
+	forward := make(map[string]string)
+	kinds := make(map[string]string)
+	for _, report := range g.fieldReports[g.mapperKey(fn, opt)] {
+		if name, ok := sourceField(fn, report); ok {
+			forward[report.Name] = name
+			kinds[report.Name] = report.Kind
+		}
+	}
+
+	var (
+		pkgPath, _   = g.opt.OutPkg()
+		sources      = mapper.NewStructFields(fn.From.Type)
+		resultFields = mapper.NewStructFields(fn.To.Type)
+		result       []mapper.StructField
+	)
+	for _, report := range g.fieldReports[g.mapperKey(inverse, opt)] {
+		// Name <- Nickname <- Name.
+		name, ok := sourceField(inverse, report)
+		if !ok || forward[name] != report.Name {
+			continue
+		}
+		// Both ways are copies, or enums.
+		if kinds[name] != report.Kind {
+			continue
+		}
+		field, ok := sources[report.Name]
+		if !ok || !field.IsAccessibleFrom(pkgPath) {
+			continue
+		}
+		if report.Kind == internal.SourceEnum && g.hasEnumDefault(field.Type, resultFields[name]) {
+			continue
+		}
+		// The types built from the fuzz inputs are also generated by
+		// testing/quick.
+		if _, ok := internal.NewFuzzInput(field.Type); !ok {
+			continue
+		}
+		result = append(result, field)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// hasEnumDefault returns true if either way of the enums maps the unknown
+// values to a default constant.
+func (g *Generator) hasEnumDefault(lhs types.Type, rhs mapper.StructField) bool {
+	for _, pair := range [][2]types.Type{{lhs, rhs.Type}, {rhs.Type, lhs}} {
+		if em, ok := g.enums.Find(pair[0], pair[1]); !ok || em.Default != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// genRoundTripBody generates the property test, which checks that the fields
+// of random sources are preserved when mapped by fn, and back by the inverse.
+func (g *Generator) genRoundTripBody(group *Group, opt mapper.OptionItem, fn, inverse *mapper.Func, fields []mapper.StructField) {
+	/*
+		Output:
+
+		m := NewMapperImpl()
+		roundTrip := func(inID int, inName string) bool {
+			a := A{
+				ID:   inID,
+				Name: inName,
+			}
+			b := m.ToB(a)
+			got := m.ToA(b)
+			return reflect.DeepEqual(got.ID, a.ID) && reflect.DeepEqual(got.Name, a.Name)
+		}
+		if err := quick.Check(roundTrip, nil); err != nil {
+			t.Error(err)
+		}
+	*/
+	if !g.genTestMapper(group, "t", opt) {
+		return
+	}
+
+	var (
+		params []Code
+		values = make(Dict)
+		checks []Code
+	)
+	for _, field := range fields {
+		params = append(params, Id("in"+field.Name).Add(internal.GenType(field.Type)))
+		values[Id(field.Name)] = Id("in" + field.Name)
+		checks = append(checks, Qual("reflect", "DeepEqual").Call(Id("got").Dot(field.Name), Id("a").Dot(field.Name)))
+	}
+
+	src := internal.GenTypeName(fn.From.Type).Values(values)
+	if mapper.IsPointer(fn.From.Type) {
+		src = Op("&").Add(src)
+	}
+
+	// The mappers may reject the random sources, e.g. strconv.Atoi.
+	genCall := func(group *Group, fn *mapper.Func, result, arg string) {
+		if !fn.Error {
+			group.Id(result).Op(":=").Add(g.testCall(opt, fn)).Call(Id(arg))
+			return
+		}
+		group.List(Id(result), Err()).Op(":=").Add(g.testCall(opt, fn)).Call(Id(arg))
+		group.If(Err().Op("!=").Nil()).Block(Return(True()))
+	}
+
+	group.Id("roundTrip").Op(":=").Func().Params(params...).Bool().BlockFunc(func(group *Group) {
+		group.Id("a").Op(":=").Add(src)
+		genCall(group, fn, "b", "a")
+		genCall(group, inverse, "got", "b")
+
+		check := checks[0]
+		for _, c := range checks[1:] {
+			check = Add(check).Op("&&").Line().Add(c)
+		}
+		group.Return(check)
+	})
+	group.If(
+		Err().Op(":=").Qual("testing/quick", "Check").Call(Id("roundTrip"), Nil()),
+		Err().Op("!=").Nil(),
+	).Block(
+		Id("t").Dot("Error").Call(Err()),
+	)
+}
@@ -0,0 +1,15 @@
+// Command mappervet checks the map tags and mapper interfaces of the packages,
+// either standalone or with go vet:
+//
+//	mappervet ./...
+//	go vet -vettool=$(which mappervet) ./...
+package main
+
+import (
+	"github.com/alextanhongpin/mapper/cmd/mapper/analyzer"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,11 @@
+package conv
+
+// BytesToString returns the string of the bytes.
+func BytesToString(b []byte) string {
+	return string(b)
+}
+
+// StringToBytes returns the bytes of the string.
+func StringToBytes(s string) []byte {
+	return []byte(s)
+}
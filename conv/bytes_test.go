@@ -0,0 +1,34 @@
+package conv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alextanhongpin/mapper/conv"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		s    string
+	}{
+		{"empty", []byte{}, ""},
+		{"ascii", []byte("hello"), "hello"},
+		{"utf8", []byte("héllo"), "héllo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if s := conv.BytesToString(tt.b); s != tt.s {
+				t.Errorf("BytesToString: want %q, got %q", tt.s, s)
+			}
+			if b := conv.StringToBytes(tt.s); !bytes.Equal(b, tt.b) {
+				t.Errorf("StringToBytes: want %v, got %v", tt.b, b)
+			}
+		})
+	}
+
+	if s := conv.BytesToString(nil); s != "" {
+		t.Errorf("BytesToString: want empty string for nil, got %q", s)
+	}
+}
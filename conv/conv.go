@@ -0,0 +1,6 @@
+// Package conv contains the common conversions, that are selected by the
+// mapper when the field types differ and no `map` tag is given.
+//
+// The functions are selected by their param and result type, so each pair of
+// types must only have one conversion.
+package conv
@@ -0,0 +1,134 @@
+package conv
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NullStringToPointer returns nil if the sql.NullString is not valid.
+func NullStringToPointer(n sql.NullString) *string {
+	if !n.Valid {
+		return nil
+	}
+	return &n.String
+}
+
+// PointerStringToNullString returns an invalid sql.NullString if the pointer is nil.
+func PointerStringToNullString(p *string) sql.NullString {
+	if p == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *p, Valid: true}
+}
+
+// NullInt64ToPointer returns nil if the sql.NullInt64 is not valid.
+func NullInt64ToPointer(n sql.NullInt64) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int64
+}
+
+// PointerInt64ToNullInt64 returns an invalid sql.NullInt64 if the pointer is nil.
+func PointerInt64ToNullInt64(p *int64) sql.NullInt64 {
+	if p == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *p, Valid: true}
+}
+
+// NullInt32ToPointer returns nil if the sql.NullInt32 is not valid.
+func NullInt32ToPointer(n sql.NullInt32) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int32
+}
+
+// PointerInt32ToNullInt32 returns an invalid sql.NullInt32 if the pointer is nil.
+func PointerInt32ToNullInt32(p *int32) sql.NullInt32 {
+	if p == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *p, Valid: true}
+}
+
+// NullInt16ToPointer returns nil if the sql.NullInt16 is not valid.
+func NullInt16ToPointer(n sql.NullInt16) *int16 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int16
+}
+
+// PointerInt16ToNullInt16 returns an invalid sql.NullInt16 if the pointer is nil.
+func PointerInt16ToNullInt16(p *int16) sql.NullInt16 {
+	if p == nil {
+		return sql.NullInt16{}
+	}
+	return sql.NullInt16{Int16: *p, Valid: true}
+}
+
+// NullByteToPointer returns nil if the sql.NullByte is not valid.
+func NullByteToPointer(n sql.NullByte) *byte {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Byte
+}
+
+// PointerByteToNullByte returns an invalid sql.NullByte if the pointer is nil.
+func PointerByteToNullByte(p *byte) sql.NullByte {
+	if p == nil {
+		return sql.NullByte{}
+	}
+	return sql.NullByte{Byte: *p, Valid: true}
+}
+
+// NullFloat64ToPointer returns nil if the sql.NullFloat64 is not valid.
+func NullFloat64ToPointer(n sql.NullFloat64) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Float64
+}
+
+// PointerFloat64ToNullFloat64 returns an invalid sql.NullFloat64 if the pointer is nil.
+func PointerFloat64ToNullFloat64(p *float64) sql.NullFloat64 {
+	if p == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *p, Valid: true}
+}
+
+// NullBoolToPointer returns nil if the sql.NullBool is not valid.
+func NullBoolToPointer(n sql.NullBool) *bool {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Bool
+}
+
+// PointerBoolToNullBool returns an invalid sql.NullBool if the pointer is nil.
+func PointerBoolToNullBool(p *bool) sql.NullBool {
+	if p == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *p, Valid: true}
+}
+
+// NullTimeToPointer returns nil if the sql.NullTime is not valid.
+func NullTimeToPointer(n sql.NullTime) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Time
+}
+
+// PointerTimeToNullTime returns an invalid sql.NullTime if the pointer is nil.
+func PointerTimeToNullTime(p *time.Time) sql.NullTime {
+	if p == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *p, Valid: true}
+}
@@ -0,0 +1,71 @@
+package conv_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/alextanhongpin/mapper/conv"
+)
+
+// testNull checks that the invalid null type and the nil pointer convert to
+// each other, and so do the valid null type and the pointer to the value.
+func testNull[N comparable, T comparable](t *testing.T, toPointer func(N) *T, fromPointer func(*T) N, valid N, value T) {
+	t.Helper()
+
+	var invalid N
+	if p := toPointer(invalid); p != nil {
+		t.Errorf("want nil for the invalid %T, got %v", invalid, *p)
+	}
+	if n := fromPointer(nil); n != invalid {
+		t.Errorf("want invalid %T for nil, got %v", invalid, n)
+	}
+
+	if p := toPointer(valid); p == nil || *p != value {
+		t.Errorf("want %v for %v, got %v", value, valid, p)
+	}
+	if n := fromPointer(&value); n != valid {
+		t.Errorf("want %v for %v, got %v", valid, value, n)
+	}
+}
+
+func TestNull(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{"NullString", func(t *testing.T) {
+			testNull(t, conv.NullStringToPointer, conv.PointerStringToNullString, sql.NullString{String: "hello", Valid: true}, "hello")
+		}},
+		{"NullStringEmpty", func(t *testing.T) {
+			// The empty string is valid.
+			testNull(t, conv.NullStringToPointer, conv.PointerStringToNullString, sql.NullString{Valid: true}, "")
+		}},
+		{"NullInt64", func(t *testing.T) {
+			testNull(t, conv.NullInt64ToPointer, conv.PointerInt64ToNullInt64, sql.NullInt64{Int64: 64, Valid: true}, int64(64))
+		}},
+		{"NullInt32", func(t *testing.T) {
+			testNull(t, conv.NullInt32ToPointer, conv.PointerInt32ToNullInt32, sql.NullInt32{Int32: 32, Valid: true}, int32(32))
+		}},
+		{"NullInt16", func(t *testing.T) {
+			testNull(t, conv.NullInt16ToPointer, conv.PointerInt16ToNullInt16, sql.NullInt16{Int16: 16, Valid: true}, int16(16))
+		}},
+		{"NullByte", func(t *testing.T) {
+			testNull(t, conv.NullByteToPointer, conv.PointerByteToNullByte, sql.NullByte{Byte: 8, Valid: true}, byte(8))
+		}},
+		{"NullFloat64", func(t *testing.T) {
+			testNull(t, conv.NullFloat64ToPointer, conv.PointerFloat64ToNullFloat64, sql.NullFloat64{Float64: 1.5, Valid: true}, 1.5)
+		}},
+		{"NullBool", func(t *testing.T) {
+			testNull(t, conv.NullBoolToPointer, conv.PointerBoolToNullBool, sql.NullBool{Bool: false, Valid: true}, false)
+		}},
+		{"NullTime", func(t *testing.T) {
+			testNull(t, conv.NullTimeToPointer, conv.PointerTimeToNullTime, sql.NullTime{Time: now, Valid: true}, now)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.test)
+	}
+}
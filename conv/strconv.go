@@ -0,0 +1,92 @@
+package conv
+
+import "strconv"
+
+// IntToString returns the decimal string of the int.
+func IntToString(i int) string {
+	return strconv.Itoa(i)
+}
+
+// StringToInt returns error if the string is not a decimal int.
+func StringToInt(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// Int64ToString returns the decimal string of the int64.
+func Int64ToString(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
+
+// StringToInt64 returns error if the string is not a decimal int64.
+func StringToInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// Int32ToString returns the decimal string of the int32.
+func Int32ToString(i int32) string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// StringToInt32 returns error if the string is not a decimal int32, e.g. it
+// is out of range.
+func StringToInt32(s string) (int32, error) {
+	i, err := strconv.ParseInt(s, 10, 32)
+	return int32(i), err
+}
+
+// UintToString returns the decimal string of the uint.
+func UintToString(i uint) string {
+	return strconv.FormatUint(uint64(i), 10)
+}
+
+// StringToUint returns error if the string is not a decimal uint, e.g. it is
+// negative.
+func StringToUint(s string) (uint, error) {
+	i, err := strconv.ParseUint(s, 10, 0)
+	return uint(i), err
+}
+
+// Uint64ToString returns the decimal string of the uint64.
+func Uint64ToString(i uint64) string {
+	return strconv.FormatUint(i, 10)
+}
+
+// StringToUint64 returns error if the string is not a decimal uint64.
+func StringToUint64(s string) (uint64, error) {
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// Float64ToString returns the shortest decimal string of the float64, without
+// exponent.
+func Float64ToString(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// StringToFloat64 returns error if the string is not a float64.
+func StringToFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// Float32ToString returns the shortest decimal string of the float32, without
+// exponent.
+func Float32ToString(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// StringToFloat32 returns error if the string is not a float32, e.g. it is out
+// of range.
+func StringToFloat32(s string) (float32, error) {
+	f, err := strconv.ParseFloat(s, 32)
+	return float32(f), err
+}
+
+// BoolToString returns "true" or "false".
+func BoolToString(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+// StringToBool returns error if the string is not a bool accepted by
+// strconv.ParseBool, e.g. "true", "1" or "F".
+func StringToBool(s string) (bool, error) {
+	return strconv.ParseBool(s)
+}
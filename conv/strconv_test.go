@@ -0,0 +1,111 @@
+package conv_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alextanhongpin/mapper/conv"
+)
+
+func TestStringToInt(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    int
+		wantErr bool
+	}{
+		{"42", 42, false},
+		{"-42", -42, false},
+		{"forty-two", 0, true},
+		{"", 0, true},
+		{"4.2", 0, true},
+		{"99999999999999999999", math.MaxInt, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := conv.StringToInt(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Fatalf("want %d, got %d", tt.want, got)
+			}
+			if err == nil {
+				if s := conv.IntToString(got); s != tt.s {
+					t.Fatalf("IntToString: want %q, got %q", tt.s, s)
+				}
+			}
+		})
+	}
+}
+
+func TestStringConversions(t *testing.T) {
+	tests := []struct {
+		name      string
+		roundTrip func(string) (string, error)
+		s         string
+		wantErr   bool
+	}{
+		{"int64", formatInt64, "9223372036854775807", false},
+		{"int64 out of range", formatInt64, "9223372036854775808", true},
+		{"int32", formatInt32, "-2147483648", false},
+		{"int32 out of range", formatInt32, "2147483648", true},
+		{"uint", formatUint, "42", false},
+		{"uint negative", formatUint, "-1", true},
+		{"uint64", formatUint64, "18446744073709551615", false},
+		{"uint64 out of range", formatUint64, "18446744073709551616", true},
+		{"float64", formatFloat64, "1.5", false},
+		{"float64 out of range", formatFloat64, "1e309", true},
+		{"float64 invalid", formatFloat64, "one", true},
+		{"float32", formatFloat32, "0.1", false},
+		{"float32 out of range", formatFloat32, "1e39", true},
+		{"bool", formatBool, "true", false},
+		{"bool invalid", formatBool, "yes", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The valid strings are formatted back unchanged.
+			got, err := tt.roundTrip(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+			if err == nil && got != tt.s {
+				t.Fatalf("want %q, got %q", tt.s, got)
+			}
+		})
+	}
+}
+
+func formatInt64(s string) (string, error) {
+	i, err := conv.StringToInt64(s)
+	return conv.Int64ToString(i), err
+}
+
+func formatInt32(s string) (string, error) {
+	i, err := conv.StringToInt32(s)
+	return conv.Int32ToString(i), err
+}
+
+func formatUint(s string) (string, error) {
+	i, err := conv.StringToUint(s)
+	return conv.UintToString(i), err
+}
+
+func formatUint64(s string) (string, error) {
+	i, err := conv.StringToUint64(s)
+	return conv.Uint64ToString(i), err
+}
+
+func formatFloat64(s string) (string, error) {
+	f, err := conv.StringToFloat64(s)
+	return conv.Float64ToString(f), err
+}
+
+func formatFloat32(s string) (string, error) {
+	f, err := conv.StringToFloat32(s)
+	return conv.Float32ToString(f), err
+}
+
+func formatBool(s string) (string, error) {
+	b, err := conv.StringToBool(s)
+	return conv.BoolToString(b), err
+}
@@ -0,0 +1,19 @@
+package conv
+
+import "time"
+
+// TimeToPointer returns nil if the time is zero.
+func TimeToPointer(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// PointerTimeToTime returns the zero time if the pointer is nil.
+func PointerTimeToTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,33 @@
+package conv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alextanhongpin/mapper/conv"
+)
+
+func TestTime(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		t    time.Time
+		p    *time.Time
+	}{
+		// The zero time is not set, like the nil pointer.
+		{"zero", time.Time{}, nil},
+		{"now", now, &now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := conv.TimeToPointer(tt.t)
+			if (p == nil) != (tt.p == nil) || (p != nil && !p.Equal(*tt.p)) {
+				t.Errorf("TimeToPointer: want %v, got %v", tt.p, p)
+			}
+			if got := conv.PointerTimeToTime(tt.p); !got.Equal(tt.t) {
+				t.Errorf("PointerTimeToTime: want %v, got %v", tt.t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package conv
+
+import "github.com/google/uuid"
+
+// UUIDToString returns the canonical form of the uuid.UUID, e.g.
+// 6ba7b810-9dad-11d1-80b4-00c04fd430c8.
+func UUIDToString(u uuid.UUID) string {
+	return u.String()
+}
+
+// StringToUUID returns error if the string is not a uuid.UUID.
+func StringToUUID(s string) (uuid.UUID, error) {
+	return uuid.Parse(s)
+}
@@ -0,0 +1,40 @@
+package conv_test
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper/conv"
+	"github.com/google/uuid"
+)
+
+func TestUUID(t *testing.T) {
+	id := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	tests := []struct {
+		name    string
+		s       string
+		want    uuid.UUID
+		wantErr bool
+	}{
+		{"valid", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", id, false},
+		{"urn", "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", id, false},
+		{"empty", "", uuid.Nil, true},
+		{"invalid", "not-a-uuid", uuid.Nil, true},
+		{"invalid length", "6ba7b810-9dad-11d1-80b4-00c04fd430c", uuid.Nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conv.StringToUUID(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StringToUUID: want error %t, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Fatalf("StringToUUID: want %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if s := conv.UUIDToString(id); s != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Fatalf("UUIDToString: got %q", s)
+	}
+}
@@ -0,0 +1,124 @@
+package mapper
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+const directivePrefix = "//mapper:"
+
+// Directive is a comment used to configure the mapper, e.g.
+//
+//	//mapper:converter
+//	func IntToString(i int) string
+type Directive struct {
+	Name string   // e.g. converter
+	Args []string // The space separated arguments following the name.
+	Pos  token.Pos
+}
+
+// String returns the directive as written in the comment.
+func (d Directive) String() string {
+	return strings.Join(append([]string{directivePrefix + d.Name}, d.Args...), " ")
+}
+
+// ParseDirectives returns the directives in the comment group.
+func ParseDirectives(doc *ast.CommentGroup) []Directive {
+	if doc == nil {
+		return nil
+	}
+
+	var result []Directive
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, directivePrefix) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(c.Text, directivePrefix))
+		if len(fields) == 0 {
+			continue
+		}
+		result = append(result, Directive{
+			Name: fields[0],
+			Args: fields[1:],
+			Pos:  c.Pos(),
+		})
+	}
+	return result
+}
+
+// Directives holds the directives of the declarations in a package, keyed by
+// the declaration name. Interface methods are keyed by the interface and
+// method name, e.g. Mapper.AtoB.
+type Directives map[string][]Directive
+
+// NewDirectives collects the directives from the doc comments of the
+// declarations in the files.
+func NewDirectives(files []*ast.File) Directives {
+	result := make(Directives)
+	add := func(name string, groups ...*ast.CommentGroup) {
+		for _, doc := range groups {
+			if directives := ParseDirectives(doc); len(directives) > 0 {
+				result[name] = append(result[name], directives...)
+			}
+		}
+	}
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil {
+					continue
+				}
+				add(d.Name.Name, d.Doc)
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						// The doc is attached to the GenDecl if the type is not
+						// declared in a group.
+						doc := s.Doc
+						if doc == nil && len(d.Specs) == 1 {
+							doc = d.Doc
+						}
+						add(s.Name.Name, doc)
+
+						if in, ok := s.Type.(*ast.InterfaceType); ok {
+							for _, method := range in.Methods.List {
+								for _, name := range method.Names {
+									add(s.Name.Name+"."+name.Name, method.Doc, method.Comment)
+								}
+							}
+						}
+					case *ast.ValueSpec:
+						doc := s.Doc
+						if doc == nil && len(d.Specs) == 1 {
+							doc = d.Doc
+						}
+						for _, name := range s.Names {
+							add(name.Name, doc, s.Comment)
+						}
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Lookup returns the directives of the declaration with the given name.
+func (d Directives) Lookup(name, directive string) []Directive {
+	var result []Directive
+	for _, dir := range d[name] {
+		if dir.Name == directive {
+			result = append(result, dir)
+		}
+	}
+	return result
+}
+
+// Has returns true if the declaration has the directive.
+func (d Directives) Has(name, directive string) bool {
+	return len(d.Lookup(name, directive)) > 0
+}
@@ -0,0 +1,196 @@
+package mapper
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/alextanhongpin/mapper/loader"
+	"golang.org/x/tools/go/packages"
+)
+
+// GenerateDirective annotates the interfaces that are generated by
+// `mapper ./...`, without a go:generate comment in each package, e.g.
+//
+//	//mapper:generate
+//	type Mapper interface {
+//		ToB(A) B
+//	}
+const GenerateDirective = "generate"
+
+// Discovered is a package with interfaces annotated with //mapper:generate,
+// and the options to generate it, or the error that prevents it, e.g. the
+// load errors of the package.
+type Discovered struct {
+	PkgPath string
+	Option  Option
+	Err     error
+}
+
+// Discover loads the packages matching the patterns at once, e.g. ./..., and
+// returns the packages with interfaces annotated with //mapper:generate. The
+// options share the flags of base.
+func Discover(base Option, patterns ...string) ([]Discovered, error) {
+	pkgs, err := loader.LoadPackages(patterns...)
+	if err != nil {
+		return nil, err
+	}
+	return DiscoverPackages(base, pkgs), nil
+}
+
+// DiscoverPackages returns the loaded packages with interfaces annotated with
+// //mapper:generate. The packages that cannot be generated are returned with
+// their error, so that they do not stop the others.
+func DiscoverPackages(base Option, pkgs []*packages.Package) []Discovered {
+	var result []Discovered
+	for _, pkg := range pkgs {
+		// The packages without annotations are skipped, even with errors, e.g.
+		// the callers of the mappers that are not generated yet.
+		if !hasGenerateDirective(pkg.Syntax) {
+			continue
+		}
+		if len(pkg.Errors) > 0 {
+			errs := make([]string, len(pkg.Errors))
+			for i, err := range pkg.Errors {
+				errs[i] = err.Error()
+			}
+			result = append(result, Discovered{
+				PkgPath: pkg.PkgPath,
+				Err:     fmt.Errorf("mapper: failed to load package %s\ndetail:\n- %s", pkg.PkgPath, strings.Join(errs, "\n- ")),
+			})
+			continue
+		}
+
+		items, err := DiscoverItems(pkg.Types, pkg.Syntax, pkg.Fset)
+		if err != nil {
+			result = append(result, Discovered{PkgPath: pkg.PkgPath, Err: err})
+			continue
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		// The combined output is named after the file of the first interface.
+		in := declFile(pkg.Syntax, pkg.Fset, items[0].Name)
+
+		opt := base
+		opt.Pkg = pkg.Types
+		opt.Syntax = pkg.Syntax
+		opt.Fset = pkg.Fset
+		opt.PkgName = pkg.Name
+		opt.PkgPath = pkg.PkgPath
+		opt.In = in
+		opt.Out = loader.FileNameFromTypeName(in, "", loader.FileName(in))
+		opt.Items = items
+		result = append(result, Discovered{PkgPath: pkg.PkgPath, Option: opt})
+	}
+	return result
+}
+
+// discover generates the packages matching the patterns, and reports the
+// result of each package. The failure of a package does not stop the others.
+func discover(fn Generator, base Option, patterns ...string) error {
+	pkgs, err := Discover(base, patterns...)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) == 0 {
+		return fmt.Errorf("mapper: no interfaces found in %s\nhelp: annotate the interfaces with //mapper:%s", strings.Join(patterns, " "), GenerateDirective)
+	}
+
+	var failed int
+	for _, pkg := range pkgs {
+		err := pkg.Err
+		if err == nil {
+			err = generatePackage(fn, pkg.Option)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("FAIL\t%s\n%s\n", pkg.PkgPath, err)
+			continue
+		}
+		fmt.Printf("ok\t%s\t%d interfaces\n", pkg.PkgPath, len(pkg.Option.Items))
+	}
+	if failed > 0 {
+		return fmt.Errorf("mapper: failed to generate %d of %d packages", failed, len(pkgs))
+	}
+	return nil
+}
+
+// generatePackage recovers the panics of the generator, which reports the
+// invalid mappings by panicking.
+func generatePackage(fn Generator, opt Option) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return fn(opt)
+}
+
+// DiscoverItems returns the interfaces annotated with //mapper:generate in
+// the files, in the order of their declaration. Each interface is generated
+// next to the file that declares it, e.g. mapper_gen.go for Mapper. It
+// returns error if an annotated type is not an interface.
+func DiscoverItems(pkg *types.Package, files []*ast.File, fset *token.FileSet) ([]OptionItem, error) {
+	directives := NewDirectives(files)
+
+	var result []OptionItem
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				name := spec.Name.Name
+				if !directives.Has(name, GenerateDirective) {
+					continue
+				}
+
+				obj := pkg.Scope().Lookup(name)
+				if obj == nil {
+					continue
+				}
+				inType, ok := obj.Type().Underlying().(*types.Interface)
+				if !ok {
+					return nil, fmt.Errorf("%s: mapper: %v is annotated with //mapper:%s, but is not an interface", fset.Position(spec.Pos()), obj, GenerateDirective)
+				}
+
+				in := fset.Position(spec.Pos()).Filename
+				result = append(result, OptionItem{
+					Path: loader.FileNameFromTypeName(in, "", name),
+					Type: inType,
+					Name: name,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// hasGenerateDirective returns true if any declaration of the files is
+// annotated with //mapper:generate.
+func hasGenerateDirective(files []*ast.File) bool {
+	for _, d := range NewDirectives(files) {
+		for _, d := range d {
+			if d.Name == GenerateDirective {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// declFile returns the name of the file that declares the top-level name.
+func declFile(files []*ast.File, fset *token.FileSet, name string) string {
+	for _, f := range files {
+		if f.Scope.Lookup(name) != nil {
+			return fset.Position(f.Pos()).Filename
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package mapper_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/loader"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestDiscoverItems(t *testing.T) {
+	pkg, files, fset := loader.LoadPackageStringFileSet(`package main
+
+type A struct{ ID int }
+type B struct{ ID int }
+
+//mapper:generate
+type UserMapper interface {
+	ToB(A) B
+}
+
+// Not annotated.
+type Skipped interface {
+	ToA(B) A
+}
+
+//mapper:generate
+type OrderMapper interface {
+	ToA(B) A
+}`)
+
+	items, err := mapper.DiscoverItems(pkg, files, fset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("want 2 items, got %d", len(items))
+	}
+
+	want := []struct{ name, file string }{
+		{"UserMapper", "user_mapper_gen.go"},
+		{"OrderMapper", "order_mapper_gen.go"},
+	}
+	for i, w := range want {
+		if got := items[i].Name; got != w.name {
+			t.Errorf("want name %q, got %q", w.name, got)
+		}
+		if got := filepath.Base(items[i].Path); got != w.file {
+			t.Errorf("want path %q, got %q", w.file, got)
+		}
+	}
+}
+
+func TestDiscoverPackages(t *testing.T) {
+	load := func(pkgPath, src string) *packages.Package {
+		pkg, files, fset := loader.LoadPackageStringFileSet(src)
+		return &packages.Package{
+			Name:    pkg.Name(),
+			PkgPath: pkgPath,
+			Types:   pkg,
+			Syntax:  files,
+			Fset:    fset,
+		}
+	}
+
+	good := load("example.com/good", `package good
+
+type A struct{ ID int }
+type B struct{ ID int }
+
+//mapper:generate
+type Mapper interface {
+	ToB(A) B
+}`)
+
+	broken := load("example.com/broken", `package broken
+
+//mapper:generate
+type Mapper interface{}`)
+	broken.Errors = []packages.Error{{Msg: "undefined: C"}}
+
+	notInterface := load("example.com/notinterface", `package notinterface
+
+//mapper:generate
+type Mapper struct{}`)
+
+	skipped := load("example.com/skipped", `package skipped
+
+type Mapper interface{}`)
+	skipped.Errors = []packages.Error{{Msg: "undefined: C"}}
+
+	pkgs := mapper.DiscoverPackages(mapper.Option{}, []*packages.Package{good, broken, notInterface, skipped})
+	if len(pkgs) != 3 {
+		t.Fatalf("want 3 packages, got %d", len(pkgs))
+	}
+
+	want := []struct {
+		pkgPath string
+		err     string
+	}{
+		{"example.com/good", ""},
+		{"example.com/broken", "undefined: C"},
+		{"example.com/notinterface", "is not an interface"},
+	}
+	for i, w := range want {
+		pkg := pkgs[i]
+		if pkg.PkgPath != w.pkgPath {
+			t.Errorf("want package %q, got %q", w.pkgPath, pkg.PkgPath)
+		}
+		if w.err == "" {
+			if pkg.Err != nil {
+				t.Errorf("%s: unexpected error: %v", pkg.PkgPath, pkg.Err)
+			}
+			if len(pkg.Option.Items) != 1 {
+				t.Errorf("%s: want 1 interface, got %d", pkg.PkgPath, len(pkg.Option.Items))
+			}
+			continue
+		}
+		if pkg.Err == nil || !strings.Contains(pkg.Err.Error(), w.err) {
+			t.Errorf("%s: want error %q, got %v", pkg.PkgPath, w.err, pkg.Err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package mapper
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// EnumConsts returns the constants of the named type T, declared in the
+// package of T and ordered by value, e.g.
+//
+//	type Status int
+//
+//	const (
+//		StatusActive Status = iota
+//		StatusBanned
+//	)
+func EnumConsts(T types.Type) []*types.Const {
+	named, ok := T.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Basic); !ok {
+		return nil
+	}
+
+	var result []*types.Const
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), T) {
+			continue
+		}
+		result = append(result, c)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return constant.Compare(result[i].Val(), token.LSS, result[j].Val())
+	})
+	return result
+}
+
+// EnumDirective renames or marks the default constant of an enum, e.g.
+//
+//	const (
+//		StatusActive Status = iota
+//		StatusBanned //mapper:enum APIStatusSuspended
+//		StatusUnknown //mapper:enum default
+//	)
+const EnumDirective = "enum"
+
+// EnumCase maps the constant of the source enum to the target enum.
+type EnumCase struct {
+	From *types.Const
+	To   *types.Const
+}
+
+// Enums matches the constants of the enum types by their name. The
+// directives are only read from the given packages.
+type Enums struct {
+	directives map[*types.Package]Directives
+}
+
+func NewEnums(pkg *types.Package, files []*ast.File) *Enums {
+	e := &Enums{directives: make(map[*types.Package]Directives)}
+	e.add(pkg, files)
+	return e
+}
+
+func (e *Enums) add(pkg *types.Package, files []*ast.File) {
+	if pkg != nil {
+		e.directives[pkg] = NewDirectives(files)
+	}
+}
+
+// Match returns the cases from the constants of lhs to rhs, and the default
+// constant of rhs, if any. No cases are returned if lhs or rhs is not an enum.
+func (e *Enums) Match(lhs, rhs types.Type) ([]EnumCase, *types.Const) {
+	from := EnumConsts(lhs)
+	to := EnumConsts(rhs)
+	if len(from) == 0 || len(to) == 0 {
+		return nil, nil
+	}
+
+	var def *types.Const
+	for _, c := range to {
+		if e.has(c, "default") {
+			def = c
+			break
+		}
+	}
+
+	var (
+		cases   []EnumCase
+		srcKeys = enumKeys(from, lhs)
+		dstKeys = enumKeys(to, rhs)
+		seen    = make(map[string]bool)
+	)
+	for _, src := range from {
+		// Constants with the same value would result in duplicate cases.
+		if seen[src.Val().ExactString()] {
+			continue
+		}
+		if dst, ok := e.match(src, srcKeys[src], to, dstKeys); ok {
+			seen[src.Val().ExactString()] = true
+			cases = append(cases, EnumCase{From: src, To: dst})
+		}
+	}
+	return cases, def
+}
+
+// match returns the target constant with the same key, e.g. StatusActive
+// matches APIStatusActive and Status_STATUS_ACTIVE. The names given in the
+// directive takes precedence.
+func (e *Enums) match(src *types.Const, srcKey string, to []*types.Const, dstKeys map[*types.Const]string) (*types.Const, bool) {
+	for _, dst := range to {
+		if e.renamed(src, dst) || e.renamed(dst, src) {
+			return dst, true
+		}
+	}
+
+	for _, dst := range to {
+		if e.isRenamed(dst) {
+			continue
+		}
+		if dstKeys[dst] == srcKey {
+			return dst, true
+		}
+	}
+	return nil, false
+}
+
+// renamed returns true if c is renamed to other with the directive.
+func (e *Enums) renamed(c, other *types.Const) bool {
+	return e.has(c, other.Name())
+}
+
+func (e *Enums) isRenamed(c *types.Const) bool {
+	for _, d := range e.lookup(c) {
+		if len(d.Args) > 0 && d.Args[0] != "default" {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Enums) has(c *types.Const, arg string) bool {
+	for _, d := range e.lookup(c) {
+		if len(d.Args) > 0 && d.Args[0] == arg {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Enums) lookup(c *types.Const) []Directive {
+	return e.directives[c.Pkg()].Lookup(c.Name(), EnumDirective)
+}
+
+// enumKeys returns the constant names without the type name and the prefix
+// shared by all the constants, in lowercase and without underscores, e.g.
+// StatusActive returns active, and Status_STATUS_ACTIVE returns active.
+func enumKeys(consts []*types.Const, T types.Type) map[*types.Const]string {
+	typeName := NewTypeName(T).Name()
+
+	names := make([]string, len(consts))
+	for i, c := range consts {
+		name := c.Name()
+		if strings.HasPrefix(name, typeName) {
+			name = strings.TrimPrefix(name[len(typeName):], "_")
+		}
+		names[i] = name
+	}
+
+	// Protobuf enums are also prefixed with the enum name, e.g. STATUS_.
+	if prefix := commonPrefix(names); len(names) > 1 {
+		if i := strings.LastIndex(prefix, "_"); i > -1 {
+			for j := range names {
+				names[j] = names[j][i+1:]
+			}
+		}
+	}
+
+	result := make(map[*types.Const]string)
+	for i, c := range consts {
+		result[c] = strings.ToLower(strings.ReplaceAll(names[i], "_", ""))
+	}
+	return result
+}
+
+func commonPrefix(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	prefix := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:548b0b7de9c1bc4b0f534d80cfc94aea38b70b4b8a9c0a0d9a6e9352a5262d8b
 
 package main
 
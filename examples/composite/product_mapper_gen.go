@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:f2851d79c58665ea5c6d7549601f241b57e68a7a933b67efc3b16d63f82a5c69
 
 package main
 
@@ -0,0 +1,35 @@
+// conv demonstrates the converters bundled in
+// github.com/alextanhongpin/mapper/conv, which are selected automatically when
+// the field types differ and no `map` tag is given.
+package main
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+type Mapper interface {
+	AtoB(A) (B, error)
+	BtoA(B) (A, error)
+}
+
+type A struct {
+	ID        uuid.UUID
+	Age       string
+	Remarks   sql.NullString
+	DeletedAt *time.Time
+	Content   []byte
+	Tags      []string
+}
+
+type B struct {
+	ID        string
+	Age       int
+	Remarks   *string
+	DeletedAt time.Time
+	Content   string
+	Tags      []string
+}
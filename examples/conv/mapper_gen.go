@@ -0,0 +1,67 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:681d470a30088f5fb11b5e03c90fe58ff4205326d53456b9413589929b35546d
+
+package main
+
+import conv "github.com/alextanhongpin/mapper/conv"
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainAToMainB(a0 A) (B, error) {
+	a0Age, err := conv.StringToInt(a0.Age)
+	if err != nil {
+		return B{}, err
+	}
+	a0Content := conv.BytesToString(a0.Content)
+	a0DeletedAt := conv.PointerTimeToTime(a0.DeletedAt)
+	a0ID := conv.UUIDToString(a0.ID)
+	a0Remarks := conv.NullStringToPointer(a0.Remarks)
+	return B{
+		Age:       a0Age,
+		Content:   a0Content,
+		DeletedAt: a0DeletedAt,
+		ID:        a0ID,
+		Remarks:   a0Remarks,
+		Tags:      a0.Tags,
+	}, nil
+}
+
+func (m *MapperImpl) mapMainBToMainA(b0 B) (A, error) {
+	b0Age := conv.IntToString(b0.Age)
+	b0Content := conv.StringToBytes(b0.Content)
+	b0ID, err := conv.StringToUUID(b0.ID)
+	if err != nil {
+		return A{}, err
+	}
+	b0Remarks := conv.PointerStringToNullString(b0.Remarks)
+	return A{
+		Age:       b0Age,
+		Content:   b0Content,
+		DeletedAt: &b0.DeletedAt,
+		ID:        b0ID,
+		Remarks:   b0Remarks,
+		Tags:      b0.Tags,
+	}, nil
+}
+
+func (m *MapperImpl) AtoB(a0 A) (B, error) {
+	a1, err := m.mapMainAToMainB(a0)
+	if err != nil {
+		return B{}, err
+	}
+	return a1, nil
+}
+
+func (m *MapperImpl) BtoA(b0 B) (A, error) {
+	b1, err := m.mapMainBToMainA(b0)
+	if err != nil {
+		return A{}, err
+	}
+	return b1, nil
+}
@@ -0,0 +1,35 @@
+// converter demonstrates the functions annotated with //mapper:converter,
+// which are selected automatically when the field types match their param and
+// result type.
+package main
+
+import (
+	"strings"
+
+	"github.com/alextanhongpin/mapper/examples/converter/money"
+)
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -converters github.com/alextanhongpin/mapper/examples/converter/money
+type Mapper interface {
+	AtoB(A) B
+	AsToBs([]A) []B
+}
+
+type Email string
+
+//mapper:converter
+func EmailToString(e Email) string {
+	return strings.ToLower(string(e))
+}
+
+type A struct {
+	Email Email
+	Price money.Cents
+	Tags  []Email
+}
+
+type B struct {
+	Email string
+	Price money.Money
+	Tags  []string
+}
@@ -0,0 +1,41 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:0166421fcb5bfa0803335db8eb179f4c97bcc67566dc5731ea42b5d7803ba18d
+
+package main
+
+import money "github.com/alextanhongpin/mapper/examples/converter/money"
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainAToMainB(a0 A) B {
+	a0Email := EmailToString(a0.Email)
+	a0Price := money.CentsToMoney(a0.Price)
+	a0Tags := make([]string, len(a0.Tags))
+	for i, each := range a0.Tags {
+		a0Tags[i] = EmailToString(each)
+	}
+	return B{
+		Email: a0Email,
+		Price: a0Price,
+		Tags:  a0Tags,
+	}
+}
+
+func (m *MapperImpl) AsToBs(a0 []A) []B {
+	a1 := make([]B, len(a0))
+	for i, each := range a0 {
+		a1[i] = m.mapMainAToMainB(each)
+	}
+	return a1
+}
+
+func (m *MapperImpl) AtoB(a0 A) B {
+	a1 := m.mapMainAToMainB(a0)
+	return a1
+}
@@ -0,0 +1,19 @@
+// money holds the converters shared across packages, which are loaded with
+// -converters github.com/alextanhongpin/mapper/examples/converter/money.
+package money
+
+import "fmt"
+
+type Cents int64
+
+type Money string
+
+//mapper:converter
+func CentsToMoney(c Cents) Money {
+	return Money(fmt.Sprintf("%.2f", float64(c)/100))
+}
+
+// FormatCents is not annotated, so it is not registered.
+func FormatCents(c Cents) Money {
+	return Money(fmt.Sprint(c))
+}
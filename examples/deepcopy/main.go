@@ -0,0 +1,29 @@
+// deepcopy demonstrates the deep copy of slices, maps and pointers, so that
+// mutating the target does not mutate the source.
+package main
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -copy deep
+type Mapper interface {
+	ToDTO(User) UserDTO
+}
+
+type Address struct {
+	Lines []string
+	City  string
+}
+
+type User struct {
+	Name      string
+	Roles     []string
+	Metadata  map[string][]string
+	Address   *Address
+	Addresses []Address
+}
+
+type UserDTO struct {
+	Name      string
+	Roles     []string
+	Metadata  map[string][]string
+	Address   *Address
+	Addresses []Address
+}
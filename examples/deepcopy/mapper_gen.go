@@ -0,0 +1,72 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:cfc830b47b9bd42162b38c4471c8b9df494e3b5d52f970ca1f33e746959ff4fe
+
+package main
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainUserToMainUserDTO(u0 User) UserDTO {
+	return UserDTO{
+		Address:   m.copyPointerMainAddress(u0.Address),
+		Addresses: m.copySliceMainAddress(u0.Addresses),
+		Metadata:  m.copyMapStringToSliceString(u0.Metadata),
+		Name:      u0.Name,
+		Roles:     m.copySliceString(u0.Roles),
+	}
+}
+
+func (m *MapperImpl) copyMainAddress(in Address) Address {
+	out := in
+	out.Lines = m.copySliceString(in.Lines)
+	return out
+}
+
+func (m *MapperImpl) copyMapStringToSliceString(in map[string][]string) map[string][]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string][]string, len(in))
+	for k, v := range in {
+		out[k] = m.copySliceString(v)
+	}
+	return out
+}
+
+func (m *MapperImpl) copyPointerMainAddress(in *Address) *Address {
+	if in == nil {
+		return nil
+	}
+	out := m.copyMainAddress(*in)
+	return &out
+}
+
+func (m *MapperImpl) copySliceMainAddress(in []Address) []Address {
+	if in == nil {
+		return nil
+	}
+	out := make([]Address, len(in))
+	for i, v := range in {
+		out[i] = m.copyMainAddress(v)
+	}
+	return out
+}
+
+func (m *MapperImpl) copySliceString(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
+
+func (m *MapperImpl) ToDTO(u0 User) UserDTO {
+	u1 := m.mapMainUserToMainUserDTO(u0)
+	return u1
+}
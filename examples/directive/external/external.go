@@ -0,0 +1,8 @@
+// Package external represents the types of a package that cannot be tagged.
+package external
+
+type User struct {
+	ExternalID string
+	Name       string
+	Password   string
+}
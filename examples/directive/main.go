@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/alextanhongpin/mapper/examples/directive/external"
+)
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+type Mapper interface {
+	// The mapping is configured per method, since the target cannot be tagged.
+	//mapper:field ExternalID <- ID using IntToString
+	ToExternal(User) external.User
+
+	// The same types can be mapped differently by another method.
+	//mapper:field ExternalID <- ID using IntToString
+	//mapper:ignore Password
+	ToPublic(User) external.User
+}
+
+type User struct {
+	ID       int
+	Name     string
+	Password string
+}
+
+func IntToString(i int) string {
+	return strconv.Itoa(i)
+}
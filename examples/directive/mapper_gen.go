@@ -0,0 +1,41 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:d2b7555cd38586b727f1a8398735a97ac516306598ea48fcaa2a1805aed8d477
+
+package main
+
+import external "github.com/alextanhongpin/mapper/examples/directive/external"
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapToExternal(u0 User) external.User {
+	u0ExternalID := IntToString(u0.ID)
+	return external.User{
+		ExternalID: u0ExternalID,
+		Name:       u0.Name,
+		Password:   u0.Password,
+	}
+}
+
+func (m *MapperImpl) mapToPublic(u0 User) external.User {
+	u0ExternalID := IntToString(u0.ID)
+	return external.User{
+		ExternalID: u0ExternalID,
+		Name:       u0.Name,
+	}
+}
+
+func (m *MapperImpl) ToExternal(u0 User) external.User {
+	u1 := m.mapToExternal(u0)
+	return u1
+}
+
+func (m *MapperImpl) ToPublic(u0 User) external.User {
+	u1 := m.mapToPublic(u0)
+	return u1
+}
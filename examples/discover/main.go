@@ -0,0 +1,21 @@
+// discover demonstrates the generation of every interface annotated with
+// //mapper:generate in the packages matching ./..., in a single run, without
+// a go:generate comment in each package.
+package main
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper ./...
+
+import (
+	"fmt"
+
+	"github.com/alextanhongpin/mapper/examples/discover/order"
+	"github.com/alextanhongpin/mapper/examples/discover/user"
+)
+
+func main() {
+	u := user.NewUserMapperImpl().ToUserDTO(user.User{ID: 1, Name: "John"})
+	fmt.Printf("%+v\n", u)
+
+	o := order.NewOrderMapperImpl().ToOrderDTO(order.Order{ID: 1, Items: []order.Item{{SKU: "A1"}}})
+	fmt.Printf("%+v\n", o)
+}
@@ -0,0 +1,25 @@
+package order
+
+//mapper:generate
+type OrderMapper interface {
+	ToOrderDTO(Order) OrderDTO
+	ToItemDTOs([]Item) []ItemDTO
+}
+
+type Order struct {
+	ID    int
+	Items []Item
+}
+
+type Item struct {
+	SKU string
+}
+
+type OrderDTO struct {
+	ID    int
+	Items []ItemDTO
+}
+
+type ItemDTO struct {
+	SKU string
+}
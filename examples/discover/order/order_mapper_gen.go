@@ -0,0 +1,40 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:17cf540672be3f81446655c3c0be3f8cb65c16c8b26f94c22b1dd784813c034c
+
+package order
+
+var _ OrderMapper = (*OrderMapperImpl)(nil)
+
+type OrderMapperImpl struct{}
+
+func NewOrderMapperImpl() *OrderMapperImpl {
+	return &OrderMapperImpl{}
+}
+
+func (o *OrderMapperImpl) mapOrderItemToOrderItemDTO(i0 Item) ItemDTO {
+	return ItemDTO{SKU: i0.SKU}
+}
+
+func (o *OrderMapperImpl) mapOrderOrderToOrderOrderDTO(o0 Order) OrderDTO {
+	o0Items := make([]ItemDTO, len(o0.Items))
+	for i, each := range o0.Items {
+		o0Items[i] = o.mapOrderItemToOrderItemDTO(each)
+	}
+	return OrderDTO{
+		ID:    o0.ID,
+		Items: o0Items,
+	}
+}
+
+func (o *OrderMapperImpl) ToItemDTOs(i0 []Item) []ItemDTO {
+	i1 := make([]ItemDTO, len(i0))
+	for i, each := range i0 {
+		i1[i] = o.mapOrderItemToOrderItemDTO(each)
+	}
+	return i1
+}
+
+func (o *OrderMapperImpl) ToOrderDTO(o0 Order) OrderDTO {
+	o1 := o.mapOrderOrderToOrderOrderDTO(o0)
+	return o1
+}
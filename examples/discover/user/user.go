@@ -0,0 +1,16 @@
+package user
+
+//mapper:generate
+type UserMapper interface {
+	ToUserDTO(User) UserDTO
+}
+
+type User struct {
+	ID   int
+	Name string
+}
+
+type UserDTO struct {
+	ID   int
+	Name string
+}
@@ -0,0 +1,24 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:7027a1e427658579ff78abb18e2d385f14432e532bdd0f70dd14d3e286264bbd
+
+package user
+
+var _ UserMapper = (*UserMapperImpl)(nil)
+
+type UserMapperImpl struct{}
+
+func NewUserMapperImpl() *UserMapperImpl {
+	return &UserMapperImpl{}
+}
+
+func (u *UserMapperImpl) mapUserUserToUserUserDTO(u0 User) UserDTO {
+	return UserDTO{
+		ID:   u0.ID,
+		Name: u0.Name,
+	}
+}
+
+func (u *UserMapperImpl) ToUserDTO(u0 User) UserDTO {
+	u1 := u.mapUserUserToUserUserDTO(u0)
+	return u1
+}
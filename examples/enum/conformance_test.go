@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	m := NewMapperImpl()
+	mappertest.ConformE(t, m.ToAPI, User{Status: StatusBanned, Roles: []Role{RoleAdmin, RoleMember}})
+	mappertest.ConformE(t, m.ToAPI, User{Status: StatusPending})
+	mappertest.ConformE(t, m.ToAPI, User{Roles: []Role{RoleGuest}})
+	mappertest.Conform(t, m.FromAPI, APIUser{Status: APIStatus_STATUS_SUSPENDED, Roles: []APIRole{APIRoleOwner}})
+}
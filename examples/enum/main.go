@@ -0,0 +1,52 @@
+// enum demonstrates the mapping of enums by the name of their constants.
+package main
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+type Mapper interface {
+	ToAPI(User) (APIUser, error)
+	FromAPI(APIUser) User
+}
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusBanned        //mapper:enum APIStatus_STATUS_SUSPENDED
+	StatusPending
+	StatusUnknown //mapper:enum default
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+	RoleGuest  Role = "guest" //mapper:enum default
+)
+
+// APIStatus mimics the enum generated by protoc-gen-go.
+type APIStatus int32
+
+const (
+	APIStatus_STATUS_UNSPECIFIED APIStatus = 0
+	APIStatus_STATUS_ACTIVE      APIStatus = 1
+	APIStatus_STATUS_SUSPENDED   APIStatus = 2
+)
+
+type APIRole string
+
+const (
+	APIRoleAdmin  APIRole = "ADMIN"
+	APIRoleMember APIRole = "MEMBER"
+	APIRoleOwner  APIRole = "OWNER"
+)
+
+type User struct {
+	Status Status
+	Roles  []Role
+}
+
+type APIUser struct {
+	Status APIStatus
+	Roles  []APIRole
+}
@@ -0,0 +1,102 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:d68bee722bb933086ce3c66ebcdc83c4911d4caaefd583684e1e011c1f161663
+
+package main
+
+import "fmt"
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainAPIUserToMainUser(a0 APIUser) User {
+	a0Roles := make([]Role, len(a0.Roles))
+	for i, each := range a0.Roles {
+		a0Roles[i] = m.mapMainAPIRoleToMainRole(each)
+	}
+	a0Status := m.mapMainAPIStatusToMainStatus(a0.Status)
+	return User{
+		Roles:  a0Roles,
+		Status: a0Status,
+	}
+}
+
+func (m *MapperImpl) mapMainUserToMainAPIUser(u0 User) (APIUser, error) {
+	u0Roles := make([]APIRole, len(u0.Roles))
+	for i, each := range u0.Roles {
+		var err error
+		u0Roles[i], err = m.mapMainRoleToMainAPIRole(each)
+		if err != nil {
+			return APIUser{}, err
+		}
+	}
+	u0Status, err := m.mapMainStatusToMainAPIStatus(u0.Status)
+	if err != nil {
+		return APIUser{}, err
+	}
+	return APIUser{
+		Roles:  u0Roles,
+		Status: u0Status,
+	}, nil
+}
+
+func (m *MapperImpl) mapMainAPIRoleToMainRole(a0 APIRole) Role {
+	switch a0 {
+	case APIRoleAdmin:
+		return RoleAdmin
+	case APIRoleMember:
+		return RoleMember
+	default:
+		return RoleGuest
+	}
+}
+
+func (m *MapperImpl) mapMainAPIStatusToMainStatus(a0 APIStatus) Status {
+	switch a0 {
+	case APIStatus_STATUS_ACTIVE:
+		return StatusActive
+	case APIStatus_STATUS_SUSPENDED:
+		return StatusBanned
+	default:
+		return StatusUnknown
+	}
+}
+
+func (m *MapperImpl) mapMainRoleToMainAPIRole(r0 Role) (APIRole, error) {
+	switch r0 {
+	case RoleAdmin:
+		return APIRoleAdmin, nil
+	case RoleMember:
+		return APIRoleMember, nil
+	default:
+		return "", fmt.Errorf("mapper: unknown main.Role %v", r0)
+	}
+}
+
+func (m *MapperImpl) mapMainStatusToMainAPIStatus(s0 Status) (APIStatus, error) {
+	switch s0 {
+	case StatusActive:
+		return APIStatus_STATUS_ACTIVE, nil
+	case StatusBanned:
+		return APIStatus_STATUS_SUSPENDED, nil
+	default:
+		return 0, fmt.Errorf("mapper: unknown main.Status %v", s0)
+	}
+}
+
+func (m *MapperImpl) FromAPI(a0 APIUser) User {
+	a1 := m.mapMainAPIUserToMainUser(a0)
+	return a1
+}
+
+func (m *MapperImpl) ToAPI(u0 User) (APIUser, error) {
+	u1, err := m.mapMainUserToMainAPIUser(u0)
+	if err != nil {
+		return APIUser{}, err
+	}
+	return u1, nil
+}
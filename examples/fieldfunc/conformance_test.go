@@ -0,0 +1,45 @@
+package main
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+	examples "github.com/alextanhongpin/mapper/examples"
+	"github.com/alextanhongpin/mapper/mappertest"
+	"github.com/google/uuid"
+)
+
+func TestConformance(t *testing.T) {
+	mapper.RegisterFunc("IntToString", IntToString)
+	mapper.RegisterFunc("NullStringToPointer", NullStringToPointer)
+	mapper.RegisterFunc("NullStringToPointerError", NullStringToPointerError)
+	mapper.RegisterFunc("PointerStringToNullString", PointerStringToNullString)
+	mapper.RegisterFunc("StringToInt", StringToInt)
+	mapper.RegisterFunc("github.com/google/uuid/Parse", uuid.Parse)
+	mapper.RegisterFunc("github.com/alextanhongpin/mapper/examples/IntToString", examples.IntToString)
+	mapper.RegisterFunc("strconv/Atoi", strconv.Atoi)
+
+	var (
+		m   = NewMapperImpl()
+		id  = uuid.New().String()
+		str = "str"
+		a   = A{
+			ID:           1,
+			IDs:          []string{id},
+			ExternalID:   2,
+			Nums:         []string{"1", "2"},
+			UUID:         id,
+			Remarks:      sql.NullString{String: "remarks", Valid: true},
+			RemarksError: sql.NullString{String: "remarks", Valid: true},
+			PtrString:    &str,
+		}
+	)
+	mappertest.ConformE(t, m.AtoB, a)
+	mappertest.ConformE(t, m.SliceAtoB, []A{a, a})
+	mappertest.ConformE(t, m.AtoB, A{UUID: "invalid"})
+	mappertest.Conform(t, m.CtoD, C{ID: 1})
+	mappertest.ConformE(t, m.ConvertImportedFunc, examples.CustomField{Num: "1"})
+	mappertest.ConformE(t, m.ConvertImportedFuncPointer, examples.CustomField{Num: "x"})
+}
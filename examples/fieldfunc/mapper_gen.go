@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:dcbc9acdf3608b7a848e1332517323e5071db342609d7a99440e5b5935c0220a
 
 package main
 
@@ -0,0 +1,42 @@
+// genfuzz demonstrates the fuzz targets generated with -fuzz, which check that
+// the mappers that return error never panic.
+package main
+
+import (
+	"errors"
+	"strconv"
+)
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -fuzz
+type Mapper interface {
+	ToOrder(OrderRequest) (Order, error)
+}
+
+type Status string
+
+type OrderRequest struct {
+	ID       string
+	Quantity *string
+	Status   Status
+	Items    []string
+	Payload  []byte
+}
+
+type Order struct {
+	ID       int `map:",strconv/Atoi"`
+	Quantity int `map:",ParseQuantity"`
+	Status   Status
+	Items    []string
+	Payload  []byte
+}
+
+var ErrQuantityRequired = errors.New("quantity is required")
+
+func ParseQuantity(s *string) (int, error) {
+	if s == nil {
+		return 0, ErrQuantityRequired
+	}
+	return strconv.Atoi(*s)
+}
+
+func main() {}
@@ -0,0 +1,40 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:68b93f3bb30c25e8db8322f71206f31f63d05010e107b65a5cbea6e1da01e446
+
+package main
+
+import "strconv"
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainOrderRequestToMainOrder(o0 OrderRequest) (Order, error) {
+	o0ID, err := strconv.Atoi(o0.ID)
+	if err != nil {
+		return Order{}, err
+	}
+	o0Quantity, err := ParseQuantity(o0.Quantity)
+	if err != nil {
+		return Order{}, err
+	}
+	return Order{
+		ID:       o0ID,
+		Items:    o0.Items,
+		Payload:  o0.Payload,
+		Quantity: o0Quantity,
+		Status:   o0.Status,
+	}, nil
+}
+
+func (m *MapperImpl) ToOrder(o0 OrderRequest) (Order, error) {
+	o1, err := m.mapMainOrderRequestToMainOrder(o0)
+	if err != nil {
+		return Order{}, err
+	}
+	return o1, nil
+}
@@ -0,0 +1,45 @@
+// gentest demonstrates the tests generated with -test, which check that the
+// fields of a populated source land in the target fields.
+package main
+
+import "strconv"
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -test
+type Mapper interface {
+	ToDTO(User) UserDTO
+	ToDTOs([]User) []UserDTO
+	ToAccount(User) (Account, error)
+}
+
+type User struct {
+	ID       int
+	Name     string
+	Email    *string
+	Tags     []string
+	Age      string
+	Password string
+}
+
+func (u User) DisplayName() string {
+	return "@" + u.Name
+}
+
+type UserDTO struct {
+	ID          string `map:",IntToString"`
+	Username    string `map:"Name"`
+	Email       *string
+	Tags        []string
+	DisplayName string
+	Password    string `map:"-"`
+}
+
+type Account struct {
+	ID  int
+	Age int `map:",strconv/Atoi"`
+}
+
+func IntToString(i int) string {
+	return strconv.Itoa(i)
+}
+
+func main() {}
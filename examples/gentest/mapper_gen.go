@@ -0,0 +1,57 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:8b139c29159bffd0a2d0fa01b7d0b09180ac2f3463e5a8ce3ebb9e02e238f2b3
+
+package main
+
+import "strconv"
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainUserToMainAccount(u0 User) (Account, error) {
+	u0Age, err := strconv.Atoi(u0.Age)
+	if err != nil {
+		return Account{}, err
+	}
+	return Account{
+		Age: u0Age,
+		ID:  u0.ID,
+	}, nil
+}
+
+func (m *MapperImpl) mapMainUserToMainUserDTO(u0 User) UserDTO {
+	u0ID := IntToString(u0.ID)
+	return UserDTO{
+		DisplayName: u0.DisplayName(),
+		Email:       u0.Email,
+		ID:          u0ID,
+		Tags:        u0.Tags,
+		Username:    u0.Name,
+	}
+}
+
+func (m *MapperImpl) ToAccount(u0 User) (Account, error) {
+	u1, err := m.mapMainUserToMainAccount(u0)
+	if err != nil {
+		return Account{}, err
+	}
+	return u1, nil
+}
+
+func (m *MapperImpl) ToDTO(u0 User) UserDTO {
+	u1 := m.mapMainUserToMainUserDTO(u0)
+	return u1
+}
+
+func (m *MapperImpl) ToDTOs(u0 []User) []UserDTO {
+	u1 := make([]UserDTO, len(u0))
+	for i, each := range u0 {
+		u1[i] = m.mapMainUserToMainUserDTO(each)
+	}
+	return u1
+}
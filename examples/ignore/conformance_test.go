@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+
+	examples "github.com/alextanhongpin/mapper/examples"
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	m := NewMapperImpl()
+	mappertest.Conform(t, m.AtoB, A{ID: 1, Str: "str", Bool: true, Slice: []string{"a"}, Map: map[string]int{"a": 1}})
+	mappertest.Conform(t, m.ExternalAtoB, examples.A{ID: 1, Str: "str"})
+}
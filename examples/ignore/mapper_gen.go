@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:e19e5c9518de59c4e15f72ee93a9745bdbfc8ac25435a130106f2ddf99b40a80
 
 package main
 
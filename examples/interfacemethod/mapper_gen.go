@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:9ce656dff6899757bf3a2ccb7ad2e866fdc18bf7016d312f7fc38aeff26b0e99
 
 package main
 
@@ -0,0 +1,20 @@
+// lenient demonstrates the lenient mode, where the target fields without
+// mapping are left at their zero value, for the types that cannot be tagged.
+package main
+
+import "net/url"
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+type Mapper interface {
+	// url.URL cannot be tagged, and only a few fields are filled.
+	//mapper:lenient
+	ToURL(Link) url.URL
+}
+
+type Link struct {
+	Scheme string
+	Host   string
+	Path   string
+}
+
+func main() {}
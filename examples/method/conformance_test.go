@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	mapper.RegisterFunc("StatusToString", StatusToString)
+
+	m := NewMapperImpl()
+	mappertest.Conform(t, m.AtoB, A{
+		id:     1,
+		str:    "str",
+		bool:   true,
+		slice:  []string{"a"},
+		m:      map[string]int{"a": 1},
+		t:      time.Now(),
+		status: "active",
+	})
+	mappertest.ConformE(t, m.CtoD, C{id: "1"})
+	mappertest.ConformE(t, m.CtoD, C{id: "x"})
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:e33803a02838b397e91a41a40169453b07926a8664f7540a7ab848de0145d579
 
 package main
 
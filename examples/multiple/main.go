@@ -0,0 +1,57 @@
+// multiple demonstrates the generation of several interfaces in one run. Each
+// interface is generated into its own file, with its own dependencies, e.g.
+// only UserMapper requires the URLBuilder.
+package main
+
+import "path/filepath"
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type UserMapper,OrderMapper
+type UserMapper interface {
+	ToUserDTO(User) UserDTO
+	ToAddressDTO(Address) AddressDTO
+}
+
+type OrderMapper interface {
+	ToOrderDTO(Order) OrderDTO
+	ToAddressDTO(Address) AddressDTO
+}
+
+type Address struct {
+	City string
+}
+
+type AddressDTO struct {
+	City string
+}
+
+type User struct {
+	Name    string
+	Avatar  string
+	Address Address
+}
+
+type UserDTO struct {
+	Name    string
+	Avatar  string `map:",URLBuilder.Build"`
+	Address AddressDTO
+}
+
+type Order struct {
+	ID       int
+	Shipping Address
+}
+
+type OrderDTO struct {
+	ID       int
+	Shipping AddressDTO
+}
+
+type URLBuilder struct {
+	Domain string
+}
+
+func (u URLBuilder) Build(path string) string {
+	return filepath.Join(u.Domain, path)
+}
+
+func main() {}
@@ -0,0 +1,34 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:f045ffd5fced11be56e9d4c1722838421fcf80f22b3eab6275fc4bcf11f79c6d
+
+package main
+
+var _ OrderMapper = (*OrderMapperImpl)(nil)
+
+type OrderMapperImpl struct{}
+
+func NewOrderMapperImpl() *OrderMapperImpl {
+	return &OrderMapperImpl{}
+}
+
+func (o *OrderMapperImpl) mapMainAddressToMainAddressDTO(a0 Address) AddressDTO {
+	return AddressDTO{City: a0.City}
+}
+
+func (o *OrderMapperImpl) mapMainOrderToMainOrderDTO(o0 Order) OrderDTO {
+	o0Shipping := o.mapMainAddressToMainAddressDTO(o0.Shipping)
+	return OrderDTO{
+		ID:       o0.ID,
+		Shipping: o0Shipping,
+	}
+}
+
+func (o *OrderMapperImpl) ToAddressDTO(a0 Address) AddressDTO {
+	a1 := o.mapMainAddressToMainAddressDTO(a0)
+	return a1
+}
+
+func (o *OrderMapperImpl) ToOrderDTO(o0 Order) OrderDTO {
+	o1 := o.mapMainOrderToMainOrderDTO(o0)
+	return o1
+}
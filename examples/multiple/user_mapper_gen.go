@@ -0,0 +1,38 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:ea80920c424a6106fe6f5067f1580f7b95688f1f239f5f24dbc1c36f3c1219b6
+
+package main
+
+var _ UserMapper = (*UserMapperImpl)(nil)
+
+type UserMapperImpl struct {
+	uRLBuilder *URLBuilder
+}
+
+func NewUserMapperImpl(uRLBuilder *URLBuilder) *UserMapperImpl {
+	return &UserMapperImpl{uRLBuilder: uRLBuilder}
+}
+
+func (u *UserMapperImpl) mapMainAddressToMainAddressDTO(a0 Address) AddressDTO {
+	return AddressDTO{City: a0.City}
+}
+
+func (u *UserMapperImpl) mapMainUserToMainUserDTO(u0 User) UserDTO {
+	u0Address := u.mapMainAddressToMainAddressDTO(u0.Address)
+	u0Avatar := u.uRLBuilder.Build(u0.Avatar)
+	return UserDTO{
+		Address: u0Address,
+		Avatar:  u0Avatar,
+		Name:    u0.Name,
+	}
+}
+
+func (u *UserMapperImpl) ToAddressDTO(a0 Address) AddressDTO {
+	a1 := u.mapMainAddressToMainAddressDTO(a0)
+	return a1
+}
+
+func (u *UserMapperImpl) ToUserDTO(u0 User) UserDTO {
+	u1 := u.mapMainUserToMainUserDTO(u0)
+	return u1
+}
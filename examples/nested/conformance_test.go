@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	examples "github.com/alextanhongpin/mapper/examples"
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	var (
+		m     = NewMapperImpl()
+		price = examples.Price{Currency: "MYR", Amount: 10}
+		book  = examples.Book{ID: 1, UserID: 1, Title: "title", Price: price}
+	)
+	mappertest.ConformE(t, m.ConvertUser, examples.User{ID: 1, Name: "john", Books: []examples.Book{book}})
+	mappertest.ConformE(t, m.ConvertBook, book)
+	mappertest.Conform(t, m.ConvertPrice, price)
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:e2beb6098ae6f9e4e05854b68fe298b6084042f877482f87d062151a51084e39
 
 package main
 
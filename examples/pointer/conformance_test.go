@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	examples "github.com/alextanhongpin/mapper/examples"
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	var (
+		m = NewMapperImpl()
+		c = C{Name: "john", Age: 10}
+	)
+	mappertest.Conform(t, m.AtoB, A{ID: 1, Str: "str", Ptr: &c, NonPtrToPointer: c})
+	mappertest.Conform(t, m.AtoB, A{ID: 1})
+	mappertest.Conform(t, m.ExternalAtoB, examples.A{ID: 1})
+	mappertest.Conform(t, m.CtoD, c)
+	mappertest.Conform(t, m.CtoDPointer, c)
+	mappertest.Conform(t, m.CPointerToDPointer, &c)
+	mappertest.Conform(t, m.CPointerToDPointer, nil)
+}
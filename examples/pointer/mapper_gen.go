@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:1692f322805cd471c69a7277fc6041012e4e892fddd64cbfe194a84de3df6896
 
 package main
 
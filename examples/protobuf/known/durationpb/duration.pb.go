@@ -0,0 +1,40 @@
+// Package durationpb fakes google.golang.org/protobuf/types/known/durationpb.
+package durationpb
+
+import (
+	"time"
+
+	"github.com/alextanhongpin/mapper/examples/protobuf/protoimpl"
+)
+
+type Duration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seconds int64
+	Nanos   int32
+}
+
+func New(d time.Duration) *Duration {
+	nanos := d.Nanoseconds()
+	return &Duration{Seconds: nanos / 1e9, Nanos: int32(nanos % 1e9)}
+}
+
+func (x *Duration) AsDuration() time.Duration {
+	return time.Duration(x.GetSeconds())*time.Second + time.Duration(x.GetNanos())
+}
+
+func (x *Duration) GetSeconds() int64 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *Duration) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+// protobuf demonstrates mapping from and to messages generated by
+// protoc-gen-go. The message internals are skipped, the fields are read
+// through the nil-safe getters, the oneof are mapped to the implementations of
+// the target interface, and the well-known types are converted to their Go
+// equivalent.
+package main
+
+import (
+	"time"
+
+	"github.com/alextanhongpin/mapper/examples/protobuf/pb"
+)
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+type Mapper interface {
+	OrderFromProto(*pb.Order) Order
+	CustomerFromProto(*pb.Customer) Customer
+	ItemFromProto(*pb.Item) Item
+	CardFromProto(*pb.Card) Card
+	BankTransferFromProto(*pb.BankTransfer) *BankTransfer
+
+	ItemToProto(Item) *pb.Item
+	ItemsToProto([]Item) []*pb.Item
+	CustomerToProto(*Customer) *pb.Customer
+
+	AuditFromProto(*pb.Audit) Audit
+	AuditToProto(Audit) *pb.Audit
+}
+
+type Order struct {
+	ID        string `map:"Id"`
+	Customer  Customer
+	Items     []Item
+	CreatedAt time.Time
+	TTL       time.Duration `map:"Ttl"`
+	Remarks   *string
+	Payment   PaymentMethod
+}
+
+type Audit struct {
+	CreatedAt time.Time
+	TTL       time.Duration `map:"Ttl"`
+	Revision  *int64
+}
+
+type Customer struct {
+	Name string
+}
+
+type Item struct {
+	Sku      string
+	Quantity int64
+}
+
+type PaymentMethod interface {
+	isPaymentMethod()
+}
+
+type Card struct {
+	Number string
+}
+
+func (Card) isPaymentMethod() {}
+
+type BankTransfer struct {
+	Iban string
+}
+
+func (*BankTransfer) isPaymentMethod() {}
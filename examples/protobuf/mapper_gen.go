@@ -0,0 +1,194 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:6ac6386b6cfd99861936cb38aef003e3f695fc7b3c09d6700a60ca40a5ead580
+
+package main
+
+import (
+	"fmt"
+	durationpb "github.com/alextanhongpin/mapper/examples/protobuf/known/durationpb"
+	timestamppb "github.com/alextanhongpin/mapper/examples/protobuf/known/timestamppb"
+	wrapperspb "github.com/alextanhongpin/mapper/examples/protobuf/known/wrapperspb"
+	pb "github.com/alextanhongpin/mapper/examples/protobuf/pb"
+	"time"
+)
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapPbAuditToMainAudit(a0 *pb.Audit) Audit {
+	a0CreatedAt := a0.GetCreatedAt()
+	var a1CreatedAt time.Time
+	if v := a0CreatedAt; v != nil {
+		a1CreatedAt = v.AsTime()
+	}
+	a0Revision := a0.GetRevision()
+	var a1Revision *int64
+	if v := a0Revision; v != nil {
+		tmp := v.GetValue()
+		a1Revision = &tmp
+	}
+	a0TTL := a0.GetTtl()
+	a1TTL := a0TTL.AsDuration()
+	return Audit{
+		CreatedAt: a1CreatedAt,
+		Revision:  a1Revision,
+		TTL:       a1TTL,
+	}
+}
+
+func (m *MapperImpl) mapMainAuditToPbAudit(a0 Audit) *pb.Audit {
+	var a0CreatedAt *timestamppb.Timestamp
+	if !a0.CreatedAt.IsZero() {
+		a0CreatedAt = timestamppb.New(a0.CreatedAt)
+	}
+	var a0Revision *wrapperspb.Int64Value
+	if a0.Revision != nil {
+		a0Revision = wrapperspb.Int64(*a0.Revision)
+	}
+	a0TTL := durationpb.New(a0.TTL)
+	return &pb.Audit{
+		CreatedAt: a0CreatedAt,
+		Revision:  a0Revision,
+		Ttl:       a0TTL,
+	}
+}
+
+func (m *MapperImpl) mapPbBankTransferToMainBankTransfer(b0 *pb.BankTransfer) BankTransfer {
+	return BankTransfer{Iban: b0.GetIban()}
+}
+
+func (m *MapperImpl) mapPbCardToMainCard(c0 *pb.Card) Card {
+	return Card{Number: c0.GetNumber()}
+}
+
+func (m *MapperImpl) mapPbCustomerToMainCustomer(c0 *pb.Customer) Customer {
+	return Customer{Name: c0.GetName()}
+}
+
+func (m *MapperImpl) mapMainCustomerToPbCustomer(c0 Customer) *pb.Customer {
+	return &pb.Customer{Name: c0.Name}
+}
+
+func (m *MapperImpl) mapPbItemToMainItem(i0 *pb.Item) Item {
+	return Item{
+		Quantity: i0.GetQuantity(),
+		Sku:      i0.GetSku(),
+	}
+}
+
+func (m *MapperImpl) mapMainItemToPbItem(i0 Item) *pb.Item {
+	return &pb.Item{
+		Quantity: i0.Quantity,
+		Sku:      i0.Sku,
+	}
+}
+
+func (m *MapperImpl) mapPbOrderToMainOrder(o0 *pb.Order) Order {
+	o0CreatedAt := o0.GetCreatedAt()
+	var o1CreatedAt time.Time
+	if v := o0CreatedAt; v != nil {
+		o1CreatedAt = v.AsTime()
+	}
+	o0Customer := o0.GetCustomer()
+	o1Customer := m.mapPbCustomerToMainCustomer(o0Customer)
+	o0Items := o0.GetItems()
+	o1Items := make([]Item, len(o0Items))
+	for i, each := range o0Items {
+		o1Items[i] = m.mapPbItemToMainItem(each)
+	}
+	o0Payment := o0.GetPayment()
+	var o1Payment PaymentMethod
+	switch v := o0Payment.(type) {
+	case nil:
+	case *pb.Order_BankTransfer:
+		if v != nil {
+			tmp := m.mapPbBankTransferToMainBankTransfer(v.BankTransfer)
+			o1Payment = &tmp
+		}
+	case *pb.Order_Card:
+		if v != nil {
+			o1Payment = m.mapPbCardToMainCard(v.Card)
+		}
+	default:
+		panic(fmt.Sprintf("mapper: unhandled variant %T of pb.isOrder_Payment", v))
+	}
+	o0Remarks := o0.GetRemarks()
+	var o1Remarks *string
+	if v := o0Remarks; v != nil {
+		tmp := v.GetValue()
+		o1Remarks = &tmp
+	}
+	o0TTL := o0.GetTtl()
+	o1TTL := o0TTL.AsDuration()
+	return Order{
+		CreatedAt: o1CreatedAt,
+		Customer:  o1Customer,
+		ID:        o0.GetId(),
+		Items:     o1Items,
+		Payment:   o1Payment,
+		Remarks:   o1Remarks,
+		TTL:       o1TTL,
+	}
+}
+
+func (m *MapperImpl) AuditFromProto(a0 *pb.Audit) Audit {
+	a1 := m.mapPbAuditToMainAudit(a0)
+	return a1
+}
+
+func (m *MapperImpl) AuditToProto(a0 Audit) *pb.Audit {
+	a1 := m.mapMainAuditToPbAudit(a0)
+	return a1
+}
+
+func (m *MapperImpl) BankTransferFromProto(b0 *pb.BankTransfer) *BankTransfer {
+	b1 := m.mapPbBankTransferToMainBankTransfer(b0)
+	b2 := &b1
+	return b2
+}
+
+func (m *MapperImpl) CardFromProto(c0 *pb.Card) Card {
+	c1 := m.mapPbCardToMainCard(c0)
+	return c1
+}
+
+func (m *MapperImpl) CustomerFromProto(c0 *pb.Customer) Customer {
+	c1 := m.mapPbCustomerToMainCustomer(c0)
+	return c1
+}
+
+func (m *MapperImpl) CustomerToProto(c0 *Customer) *pb.Customer {
+	var c1 *pb.Customer
+	if c0 != nil {
+		c1 = m.mapMainCustomerToPbCustomer(*c0)
+	}
+	return c1
+}
+
+func (m *MapperImpl) ItemFromProto(i0 *pb.Item) Item {
+	i1 := m.mapPbItemToMainItem(i0)
+	return i1
+}
+
+func (m *MapperImpl) ItemToProto(i0 Item) *pb.Item {
+	i1 := m.mapMainItemToPbItem(i0)
+	return i1
+}
+
+func (m *MapperImpl) ItemsToProto(i0 []Item) []*pb.Item {
+	i1 := make([]*pb.Item, len(i0))
+	for i, each := range i0 {
+		i1[i] = m.mapMainItemToPbItem(each)
+	}
+	return i1
+}
+
+func (m *MapperImpl) OrderFromProto(o0 *pb.Order) Order {
+	o1 := m.mapPbOrderToMainOrder(o0)
+	return o1
+}
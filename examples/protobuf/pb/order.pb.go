@@ -0,0 +1,223 @@
+// Package pb fakes the code generated by protoc-gen-go for order.proto:
+//
+//	message Order {
+//	  string id = 1;
+//	  Customer customer = 2;
+//	  repeated Item items = 3;
+//	  google.protobuf.Timestamp created_at = 4;
+//	  google.protobuf.Duration ttl = 5;
+//	  google.protobuf.StringValue remarks = 6;
+//	  oneof payment {
+//	    Card card = 7;
+//	    BankTransfer bank_transfer = 8;
+//	  }
+//	}
+//
+//	message Audit {
+//	  google.protobuf.Timestamp created_at = 1;
+//	  google.protobuf.Duration ttl = 2;
+//	  google.protobuf.Int64Value revision = 3;
+//	}
+package pb
+
+import (
+	"github.com/alextanhongpin/mapper/examples/protobuf/known/durationpb"
+	"github.com/alextanhongpin/mapper/examples/protobuf/known/timestamppb"
+	"github.com/alextanhongpin/mapper/examples/protobuf/known/wrapperspb"
+	"github.com/alextanhongpin/mapper/examples/protobuf/protoimpl"
+)
+
+type Order struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Customer  *Customer               `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+	Items     []*Item                 `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Ttl       *durationpb.Duration    `protobuf:"bytes,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Remarks   *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=remarks,proto3" json:"remarks,omitempty"`
+	// Types that are assignable to Payment:
+	//	*Order_Card
+	//	*Order_BankTransfer
+	Payment isOrder_Payment `protobuf_oneof:"payment"`
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *Order) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *Order) GetRemarks() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Remarks
+	}
+	return nil
+}
+
+func (m *Order) GetPayment() isOrder_Payment {
+	if m != nil {
+		return m.Payment
+	}
+	return nil
+}
+
+func (x *Order) GetCard() *Card {
+	if x, ok := x.GetPayment().(*Order_Card); ok {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *Order) GetBankTransfer() *BankTransfer {
+	if x, ok := x.GetPayment().(*Order_BankTransfer); ok {
+		return x.BankTransfer
+	}
+	return nil
+}
+
+type isOrder_Payment interface {
+	isOrder_Payment()
+}
+
+type Order_Card struct {
+	Card *Card `protobuf:"bytes,7,opt,name=card,proto3,oneof"`
+}
+
+type Order_BankTransfer struct {
+	BankTransfer *BankTransfer `protobuf:"bytes,8,opt,name=bank_transfer,json=bankTransfer,proto3,oneof"`
+}
+
+func (*Order_Card) isOrder_Payment() {}
+
+func (*Order_BankTransfer) isOrder_Payment() {}
+
+type Customer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Customer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sku      string `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity int64  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *Item) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Item) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type Card struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number string `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *Card) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+type BankTransfer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Iban string `protobuf:"bytes,1,opt,name=iban,proto3" json:"iban,omitempty"`
+}
+
+func (x *BankTransfer) GetIban() string {
+	if x != nil {
+		return x.Iban
+	}
+	return ""
+}
+
+type Audit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Ttl       *durationpb.Duration   `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Revision  *wrapperspb.Int64Value `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *Audit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Audit) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *Audit) GetRevision() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
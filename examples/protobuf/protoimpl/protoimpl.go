@@ -0,0 +1,9 @@
+// Package protoimpl fakes google.golang.org/protobuf/runtime/protoimpl, so that
+// the examples do not depend on the protobuf module.
+package protoimpl
+
+type (
+	MessageState  struct{}
+	SizeCache     = int32
+	UnknownFields = []byte
+)
@@ -0,0 +1,30 @@
+package main
+
+import "time"
+
+// The funcs are generated without the mapper interface and struct.
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper
+
+//mapper:func
+var ToDTO func(User) UserDTO
+
+//mapper:func
+var ToDTOs func([]User) ([]UserDTO, error)
+
+//mapper:func
+//mapper:ignore Email
+var ToPublicDTO func(*User) *UserDTO
+
+type User struct {
+	ID        int
+	Name      string
+	Email     string
+	CreatedAt time.Time
+}
+
+type UserDTO struct {
+	ID        int
+	Name      string
+	Email     string
+	CreatedAt time.Time
+}
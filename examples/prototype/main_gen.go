@@ -0,0 +1,49 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:ebc8d4bc23cf251b7ceb23bc03d954a92386148bfba5b5409a3b09154512e59d
+
+package main
+
+func mapMainUserToMainUserDTO(u0 User) UserDTO {
+	return UserDTO{
+		CreatedAt: u0.CreatedAt,
+		Email:     u0.Email,
+		ID:        u0.ID,
+		Name:      u0.Name,
+	}
+}
+
+func mapToPublicDTO(u0 User) UserDTO {
+	return UserDTO{
+		CreatedAt: u0.CreatedAt,
+		ID:        u0.ID,
+		Name:      u0.Name,
+	}
+}
+
+func init() {
+	ToDTO = func(u0 User) UserDTO {
+		u1 := mapMainUserToMainUserDTO(u0)
+		return u1
+	}
+}
+
+func init() {
+	ToDTOs = func(u0 []User) ([]UserDTO, error) {
+		u1 := make([]UserDTO, len(u0))
+		for i, each := range u0 {
+			u1[i] = mapMainUserToMainUserDTO(each)
+		}
+		return u1, nil
+	}
+}
+
+func init() {
+	ToPublicDTO = func(u0 *User) *UserDTO {
+		var u1 *UserDTO
+		if u0 != nil {
+			tmp := mapToPublicDTO(*u0)
+			u1 = &tmp
+		}
+		return u1
+	}
+}
@@ -0,0 +1,48 @@
+// refs demonstrates the mapping of cyclic graphs, where the pointers that are
+// already mapped are reused, so that shared references are preserved and
+// cycles are terminated. The same source may be mapped to different targets,
+// e.g. the author and the editor of a post.
+package main
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -refs
+type Mapper interface {
+	ToDTO(*Category) *CategoryDTO
+	ToDTOs([]*Category) []*CategoryDTO
+	ToPostDTO(*Post) *PostDTO
+	ToUserDTO(User) UserDTO
+	ToUserSummary(User) UserSummary
+}
+
+type Category struct {
+	Name     string
+	Parent   *Category
+	Children []*Category
+}
+
+type CategoryDTO struct {
+	Name     string
+	Parent   *CategoryDTO
+	Children []*CategoryDTO
+}
+
+type User struct {
+	Name string
+}
+
+type UserDTO struct {
+	Name string
+}
+
+type UserSummary struct {
+	Name string
+}
+
+type Post struct {
+	Author *User
+	Editor *User
+}
+
+type PostDTO struct {
+	Author *UserDTO
+	Editor *UserSummary
+}
@@ -0,0 +1,131 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:e93d34c33a277183f0d507fc3e07bb6a5bb1ac2f29ff1cc5f0a47fe4453ec050
+
+package main
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainCategoryToMainCategoryDTO(c0 Category, visited map[interface{}]interface{}) CategoryDTO {
+	c0Children := make([]*CategoryDTO, len(c0.Children))
+	for i, each := range c0.Children {
+		c0Children[i] = m.mapRefMainCategoryToMainCategoryDTO(each, visited)
+	}
+	c0Parent := m.mapRefMainCategoryToMainCategoryDTO(c0.Parent, visited)
+	return CategoryDTO{
+		Children: c0Children,
+		Name:     c0.Name,
+		Parent:   c0Parent,
+	}
+}
+
+func (m *MapperImpl) mapMainPostToMainPostDTO(p0 Post, visited map[interface{}]interface{}) PostDTO {
+	p0Author := m.mapRefMainUserToMainUserDTO(p0.Author, visited)
+	p0Editor := m.mapRefMainUserToMainUserSummary(p0.Editor, visited)
+	return PostDTO{
+		Author: p0Author,
+		Editor: p0Editor,
+	}
+}
+
+func (m *MapperImpl) mapMainUserToMainUserDTO(u0 User, visited map[interface{}]interface{}) UserDTO {
+	return UserDTO{Name: u0.Name}
+}
+
+func (m *MapperImpl) mapMainUserToMainUserSummary(u0 User, visited map[interface{}]interface{}) UserSummary {
+	return UserSummary{Name: u0.Name}
+}
+
+func (m *MapperImpl) ToDTO(c0 *Category) *CategoryDTO {
+	visited := make(map[interface{}]interface{})
+	c1 := m.mapRefMainCategoryToMainCategoryDTO(c0, visited)
+	return c1
+}
+
+func (m *MapperImpl) ToDTOs(c0 []*Category) []*CategoryDTO {
+	visited := make(map[interface{}]interface{})
+	c1 := make([]*CategoryDTO, len(c0))
+	for i, each := range c0 {
+		c1[i] = m.mapRefMainCategoryToMainCategoryDTO(each, visited)
+	}
+	return c1
+}
+
+func (m *MapperImpl) ToPostDTO(p0 *Post) *PostDTO {
+	visited := make(map[interface{}]interface{})
+	p1 := m.mapRefMainPostToMainPostDTO(p0, visited)
+	return p1
+}
+
+func (m *MapperImpl) ToUserDTO(u0 User) UserDTO {
+	visited := make(map[interface{}]interface{})
+	u1 := m.mapMainUserToMainUserDTO(u0, visited)
+	return u1
+}
+
+func (m *MapperImpl) ToUserSummary(u0 User) UserSummary {
+	visited := make(map[interface{}]interface{})
+	u1 := m.mapMainUserToMainUserSummary(u0, visited)
+	return u1
+}
+
+func (m *MapperImpl) mapRefMainCategoryToMainCategoryDTO(c0 *Category, visited map[interface{}]interface{}) *CategoryDTO {
+	if c0 == nil {
+		return nil
+	}
+	key := [2]interface{}{c0, (*CategoryDTO)(nil)}
+	if c1, ok := visited[key]; ok {
+		return c1.(*CategoryDTO)
+	}
+	c1 := new(CategoryDTO)
+	visited[key] = c1
+	*c1 = m.mapMainCategoryToMainCategoryDTO(*c0, visited)
+	return c1
+}
+
+func (m *MapperImpl) mapRefMainPostToMainPostDTO(p0 *Post, visited map[interface{}]interface{}) *PostDTO {
+	if p0 == nil {
+		return nil
+	}
+	key := [2]interface{}{p0, (*PostDTO)(nil)}
+	if p1, ok := visited[key]; ok {
+		return p1.(*PostDTO)
+	}
+	p1 := new(PostDTO)
+	visited[key] = p1
+	*p1 = m.mapMainPostToMainPostDTO(*p0, visited)
+	return p1
+}
+
+func (m *MapperImpl) mapRefMainUserToMainUserDTO(u0 *User, visited map[interface{}]interface{}) *UserDTO {
+	if u0 == nil {
+		return nil
+	}
+	key := [2]interface{}{u0, (*UserDTO)(nil)}
+	if u1, ok := visited[key]; ok {
+		return u1.(*UserDTO)
+	}
+	u1 := new(UserDTO)
+	visited[key] = u1
+	*u1 = m.mapMainUserToMainUserDTO(*u0, visited)
+	return u1
+}
+
+func (m *MapperImpl) mapRefMainUserToMainUserSummary(u0 *User, visited map[interface{}]interface{}) *UserSummary {
+	if u0 == nil {
+		return nil
+	}
+	key := [2]interface{}{u0, (*UserSummary)(nil)}
+	if u1, ok := visited[key]; ok {
+		return u1.(*UserSummary)
+	}
+	u1 := new(UserSummary)
+	visited[key] = u1
+	*u1 = m.mapMainUserToMainUserSummary(*u0, visited)
+	return u1
+}
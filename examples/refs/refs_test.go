@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestSharedReferences(t *testing.T) {
+	m := NewMapperImpl()
+
+	parent := &Category{Name: "parent"}
+	child := &Category{Name: "child", Parent: parent}
+	parent.Children = []*Category{child, child}
+
+	dto := m.ToDTO(parent)
+	if dto.Children[0] != dto.Children[1] {
+		t.Fatal("want the shared child to be mapped once")
+	}
+	if dto.Children[0].Parent != dto {
+		t.Fatal("want the cycle back to the parent to be preserved")
+	}
+
+	dtos := m.ToDTOs([]*Category{parent, child})
+	if dtos[1] != dtos[0].Children[0] {
+		t.Fatal("want the references to be shared across the slice")
+	}
+}
+
+func TestSharedReferencesDifferentTargets(t *testing.T) {
+	m := NewMapperImpl()
+
+	user := &User{Name: "john"}
+	dto := m.ToPostDTO(&Post{Author: user, Editor: user})
+	if dto.Author == nil || dto.Author.Name != "john" {
+		t.Fatalf("want author john, got %+v", dto.Author)
+	}
+	if dto.Editor == nil || dto.Editor.Name != "john" {
+		t.Fatalf("want editor john, got %+v", dto.Editor)
+	}
+}
@@ -0,0 +1,21 @@
+// registry demonstrates the registration of the mappers for mapper.Map, e.g.
+//
+//	RegisterMapperImpl(NewMapperImpl())
+//	dto, err := mapper.Map[User, UserDTO](user)
+package main
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -register
+type Mapper interface {
+	ToDTO(User) UserDTO
+	ToDTOs([]User) []UserDTO
+}
+
+type User struct {
+	ID   int
+	Name string
+}
+
+type UserDTO struct {
+	ID   int
+	Name string
+}
@@ -0,0 +1,32 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:d80201304a2e57c904924d7ffbe1a70ad3758e10da79389b969ddb2faa1f99c2
+
+package main
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainUserToMainUserDTO(u0 User) UserDTO {
+	return UserDTO{
+		ID:   u0.ID,
+		Name: u0.Name,
+	}
+}
+
+func (m *MapperImpl) ToDTO(u0 User) UserDTO {
+	u1 := m.mapMainUserToMainUserDTO(u0)
+	return u1
+}
+
+func (m *MapperImpl) ToDTOs(u0 []User) []UserDTO {
+	u1 := make([]UserDTO, len(u0))
+	for i, each := range u0 {
+		u1[i] = m.mapMainUserToMainUserDTO(each)
+	}
+	return u1
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	m := NewMapperImpl()
+	mappertest.Conform(t, m.AtoB, A{ID: 1, Name: "john", FromA: "a"})
+}
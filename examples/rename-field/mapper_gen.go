@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:399fa186f85ff59c4d4c4b1409081a070c94a8ad8735236fb53f5349fc553991
 
 package main
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:f0668ff5b1c25df9918bfaaf6561393484c8d80ef50ab1a455b42efc674fef64
 
 package main
 
@@ -11,8 +12,7 @@ func NewMapperImpl() *MapperImpl {
 }
 
 func (m *MapperImpl) mapMainAToMainB(a0 A) B {
-	a0Status := a0.CustomStatus()
-	return B{Status: a0Status}
+	return B{Status: a0.CustomStatus()}
 }
 
 func (m *MapperImpl) AtoB(a0 A) B {
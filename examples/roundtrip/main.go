@@ -0,0 +1,40 @@
+// roundtrip demonstrates the round trip tests generated with -test, for the
+// methods that map the target back to the source.
+package main
+
+import "strconv"
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -test
+type Mapper interface {
+	//mapper:field ID <- ID using IntToString
+	//mapper:field Nickname <- Name
+	//mapper:ignore Password
+	ToDTO(User) UserDTO
+
+	//mapper:field ID <- ID using strconv/Atoi
+	//mapper:field Name <- Nickname
+	//mapper:field Password <- Nickname
+	ToUser(UserDTO) (User, error)
+}
+
+type User struct {
+	ID       int
+	Name     string
+	Email    *string
+	Tags     []string
+	Password string
+}
+
+type UserDTO struct {
+	ID       string
+	Nickname string
+	Email    *string
+	Tags     []string
+	Password string
+}
+
+func IntToString(i int) string {
+	return strconv.Itoa(i)
+}
+
+func main() {}
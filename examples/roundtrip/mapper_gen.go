@@ -0,0 +1,51 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:5208ab36c56445930584587c71c8b68bc9d5827a9437a6e44f37019f055de131
+
+package main
+
+import "strconv"
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapToDTO(u0 User) UserDTO {
+	u0ID := IntToString(u0.ID)
+	return UserDTO{
+		Email:    u0.Email,
+		ID:       u0ID,
+		Nickname: u0.Name,
+		Tags:     u0.Tags,
+	}
+}
+
+func (m *MapperImpl) mapToUser(u0 UserDTO) (User, error) {
+	u0ID, err := strconv.Atoi(u0.ID)
+	if err != nil {
+		return User{}, err
+	}
+	return User{
+		Email:    u0.Email,
+		ID:       u0ID,
+		Name:     u0.Nickname,
+		Password: u0.Nickname,
+		Tags:     u0.Tags,
+	}, nil
+}
+
+func (m *MapperImpl) ToDTO(u0 User) UserDTO {
+	u1 := m.mapToDTO(u0)
+	return u1
+}
+
+func (m *MapperImpl) ToUser(u0 UserDTO) (User, error) {
+	u1, err := m.mapToUser(u0)
+	if err != nil {
+		return User{}, err
+	}
+	return u1, nil
+}
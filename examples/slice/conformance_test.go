@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+	examples "github.com/alextanhongpin/mapper/examples"
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	mapper.RegisterFunc("Upper", Upper)
+	mapper.RegisterFunc("AddOne", AddOne)
+	mapper.RegisterFunc("Join", Join)
+	mapper.RegisterFunc("strconv/Atoi", strconv.Atoi)
+
+	var (
+		m = NewMapperImpl()
+		a = A{ID: 1, Str: "str", Slice: []string{"a", "b"}, Ints: []int{1, 2}}
+	)
+	mappertest.Conform(t, m.AtoB, a)
+	mappertest.Conform(t, m.SliceAtoB, []A{a, a})
+	mappertest.Conform(t, m.SliceAtoB, nil)
+	mappertest.Conform(t, m.ExternalAtoB, []examples.A{{ID: 1}})
+	mappertest.ConformE(t, m.CtoD, C{Ints: []string{"1", "2"}, Items: []string{"a", "b"}})
+	mappertest.ConformE(t, m.CtoD, C{Ints: []string{"x"}})
+}
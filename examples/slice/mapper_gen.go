@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:2f93bbd693d681f657c59096be39d9788ae03f6137c5af0a3efd9eb83fdb2b60
 
 package main
 
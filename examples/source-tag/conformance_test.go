@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	mapper.RegisterFunc("IntToString", IntToString)
+
+	m := NewMapperImpl()
+	mappertest.Conform(t, m.AtoB, A{ID: 1, Name: "john", Password: "secret"})
+}
@@ -0,0 +1,29 @@
+// source-tag demonstrates how to use the `map` tag on the source struct, when
+// the target struct cannot be annotated, e.g. generated or third-party types.
+//
+// Tags on the target struct take precedence. The source tag only supplies the
+// function when the target field does not declare one.
+package main
+
+import "fmt"
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+type Mapper interface {
+	AtoB(A) B
+}
+
+type A struct {
+	ID       int    `map:"ExternalID,IntToString"` // Exposed as `ExternalID`, and converted to string.
+	Name     string `map:"FullName"`               // Exposed as `FullName`.
+	Password string `map:"-"`                      // Never mapped.
+}
+
+// B could be a generated type that cannot be tagged.
+type B struct {
+	ExternalID string
+	FullName   string
+}
+
+func IntToString(i int) string {
+	return fmt.Sprint(i)
+}
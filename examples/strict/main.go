@@ -0,0 +1,27 @@
+// strict demonstrates the strict mode, where every exported source field must
+// be read, tagged `map:"-"`, or dropped by the method.
+package main
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+
+//mapper:strict
+type Mapper interface {
+	//mapper:drop PasswordHash
+	ToDTO(User) UserDTO
+}
+
+type User struct {
+	ID           int
+	Name         string
+	Email        string
+	PasswordHash string
+	Version      int `map:"-"`
+}
+
+type UserDTO struct {
+	ID    int
+	Name  string
+	Email string
+}
+
+func main() {}
@@ -0,0 +1,25 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:04af10e3c8b68197039c414f885a706098e099a68370f1898e4f39dbbdc7fd2a
+
+package main
+
+var _ Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapMainUserToMainUserDTO(u0 User) UserDTO {
+	return UserDTO{
+		Email: u0.Email,
+		ID:    u0.ID,
+		Name:  u0.Name,
+	}
+}
+
+func (m *MapperImpl) ToDTO(u0 User) UserDTO {
+	u1 := m.mapMainUserToMainUserDTO(u0)
+	return u1
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+	examples "github.com/alextanhongpin/mapper/examples"
+	"github.com/alextanhongpin/mapper/mappertest"
+)
+
+func TestConformance(t *testing.T) {
+	var (
+		u        = &URLBuilder{Domain: "example.com"}
+		external = &examples.URLBuilder{Domain: "external.com"}
+	)
+	// The methods are registered with the dependencies of the mapper.
+	mapper.RegisterFunc("URLBuilder.Build", u.Build)
+	mapper.RegisterFunc("github.com/alextanhongpin/mapper/examples/URLBuilder.Build", external.Build)
+
+	m := NewMapperImpl(external, u)
+	mappertest.ConformE(t, m.AtoB, A{URL: "a", ExternalURL: "b"})
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:e918ce8d39f1ff4905206122310b7635870560334cfd3c287128b1144d2a64dd
 
 package main
 
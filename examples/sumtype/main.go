@@ -0,0 +1,53 @@
+// sumtype demonstrates the mapping of interface fields, by dispatching each
+// implementation in the package to the mapper whose result implements the
+// target interface.
+package main
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper
+type Mapper interface {
+	ToAPI(Order) (APIOrder, error)
+	CardToAPI(Card) APICard
+	BankTransferToAPI(BankTransfer) (APIBankTransfer, error)
+}
+
+type PaymentMethod interface {
+	isPaymentMethod()
+}
+
+type Card struct {
+	Number string
+}
+
+func (Card) isPaymentMethod() {}
+
+type BankTransfer struct {
+	IBAN string
+}
+
+func (*BankTransfer) isPaymentMethod() {}
+
+type Order struct {
+	ID      string
+	Payment PaymentMethod
+}
+
+type APIPaymentMethod interface {
+	PaymentType() string
+}
+
+type APICard struct {
+	Number string
+}
+
+func (APICard) PaymentType() string { return "card" }
+
+type APIBankTransfer struct {
+	IBAN string
+}
+
+func (*APIBankTransfer) PaymentType() string { return "bank_transfer" }
+
+type APIOrder struct {
+	ID      string
+	Payment APIPaymentMethod
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+// Wallet is not known to the generator, since it is only declared in the
+// tests.
+type Wallet struct{}
+
+func (Wallet) isPaymentMethod() {}
+
+func TestVariants(t *testing.T) {
+	m := NewMapperImpl()
+
+	tests := []struct {
+		name    string
+		payment PaymentMethod
+		want    APIPaymentMethod
+	}{
+		{"nil", nil, nil},
+		{"value", Card{Number: "1"}, APICard{Number: "1"}},
+		{"pointer to value", &Card{Number: "1"}, APICard{Number: "1"}},
+		{"nil pointer", (*BankTransfer)(nil), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.ToAPI(Order{Payment: tt.payment})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Payment != tt.want {
+				t.Fatalf("want %#v, got %#v", tt.want, got.Payment)
+			}
+		})
+	}
+
+	if _, err := m.ToAPI(Order{Payment: Wallet{}}); err == nil {
+		t.Fatal("want error for the unhandled variant")
+	}
+}
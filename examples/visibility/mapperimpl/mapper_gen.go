@@ -0,0 +1,26 @@
+// Code generated by github.com/alextanhongpin/mapper, DO NOT EDIT.
+// Input hash: sha256:b675dffc82cc5e4d19fa871f77cb5d639edbc54dc91dcf86d8cb47f60a764287
+
+package mapperimpl
+
+import visibility "github.com/alextanhongpin/mapper/examples/visibility"
+
+var _ visibility.Mapper = (*MapperImpl)(nil)
+
+type MapperImpl struct{}
+
+func NewMapperImpl() *MapperImpl {
+	return &MapperImpl{}
+}
+
+func (m *MapperImpl) mapVisibilityUserToVisibilityUserDTO(u0 visibility.User) visibility.UserDTO {
+	return visibility.UserDTO{
+		ID:   u0.GetID(),
+		Name: u0.GetName(),
+	}
+}
+
+func (m *MapperImpl) UserToUserDTO(u0 visibility.User) visibility.UserDTO {
+	u1 := m.mapVisibilityUserToVisibilityUserDTO(u0)
+	return u1
+}
@@ -0,0 +1,26 @@
+// visibility demonstrates generating the mapper into another package with
+// `-out`. The generated code can only reference exported members, so the
+// unexported source fields are read through their exported getters.
+package visibility
+
+//go:generate go run github.com/alextanhongpin/mapper/cmd/mapper -type Mapper -out ./mapperimpl
+type Mapper interface {
+	UserToUserDTO(User) UserDTO
+}
+
+type User struct {
+	id   int
+	name string
+}
+
+func NewUser(id int, name string) User {
+	return User{id: id, name: name}
+}
+
+func (u User) GetID() int      { return u.id }
+func (u User) GetName() string { return u.name }
+
+type UserDTO struct {
+	ID   int    `map:"id"`
+	Name string `map:"name"`
+}
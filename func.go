@@ -155,6 +155,12 @@ func (f *Func) Normalize() *Func {
 	return f.Norm
 }
 
+// IsAccessibleFrom returns true if the func can be referenced by code in the
+// package pkgPath.
+func (f *Func) IsAccessibleFrom(pkgPath string) bool {
+	return f.Fn.Exported() || f.PkgPath == pkgPath
+}
+
 // RequiresInputPointer returns true if the input needs to be converted into a pointer.
 func (f *Func) RequiresInputPointer(in types.Type) bool {
 	return !IsPointer(in) && IsPointer(f.From.Type)
@@ -177,8 +183,8 @@ func NormFunc(name string, fn *types.Func) *types.Func {
 }
 
 func NormFuncFromTypes(name string, param, result types.Type) *types.Func {
-	param = NewUnderlyingType(param)
-	result = NewUnderlyingType(result)
+	param = normType(param)
+	result = normType(result)
 
 	namedParam := NewNamedVisitor(param)
 	namedResult := NewNamedVisitor(result)
@@ -189,3 +195,13 @@ func NormFuncFromTypes(name string, param, result types.Type) *types.Func {
 	sig := types.NewSignature(nil, params, results, false)
 	return types.NewFunc(token.NoPos, nil, name, sig)
 }
+
+// normType returns the underlying type. Protobuf messages are always passed by
+// pointer, since they must not be copied.
+func normType(T types.Type) types.Type {
+	U := NewUnderlyingType(T)
+	if IsProtoMessage(U) {
+		return types.NewPointer(U)
+	}
+	return U
+}
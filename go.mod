@@ -1,14 +1,18 @@
 module github.com/alextanhongpin/mapper
 
-go 1.16
+go 1.18
 
 require (
 	github.com/alextanhongpin/pkg v0.17.0
 	github.com/dave/jennifer v1.4.1
 	github.com/google/go-cmp v0.5.5
 	github.com/google/uuid v1.3.0
-	golang.org/x/mod v0.5.0 // indirect
-	golang.org/x/sys v0.0.0-20210818153620-00dd8d7831e7 // indirect
 	golang.org/x/tools v0.1.5
 	gopkg.in/yaml.v2 v2.4.0
 )
+
+require (
+	golang.org/x/mod v0.5.0 // indirect
+	golang.org/x/sys v0.0.0-20210818153620-00dd8d7831e7 // indirect
+	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+)
@@ -10,7 +10,11 @@ import (
 )
 
 // FullPath returns the full path to the package, relative to the caller.
+// Absolute paths, e.g. the files of the loaded packages, are returned as is.
 func FullPath(rel string) string {
+	if filepath.IsAbs(rel) {
+		return rel
+	}
 	path, err := os.Getwd()
 	if err != nil {
 		panic(fmt.Errorf("failed to get package directory: %v", err))
@@ -40,13 +40,13 @@ func PackageName(prefix, path string) string {
 	return filepath.Base(PackagePath(prefix, path))
 }
 
+// LoadPackage loads the types of the package. The files are parsed separately
+// to read the comments, since loading the syntax requires the types to be
+// checked from source.
 func LoadPackage(path string) *packages.Package {
-	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports,
-	}
-	pkgs, err := packages.Load(cfg, fmt.Sprintf(path))
+	pkgs, err := LoadPackages(path)
 	if err != nil {
-		panic(fmt.Errorf("loader: failed to load package: %v", err))
+		panic(err)
 	}
 	if packages.PrintErrors(pkgs) > 0 {
 		os.Exit(1)
@@ -54,13 +54,96 @@ func LoadPackage(path string) *packages.Package {
 	return pkgs[0]
 }
 
+// TryLoadPackage is like LoadPackage, but returns the errors of the package
+// instead of exiting, e.g. for the analyzer, which runs inside the vet driver.
+func TryLoadPackage(path string) (*packages.Package, error) {
+	pkgs, err := LoadPackages(path)
+	if err != nil {
+		return nil, err
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		errs := make([]string, len(pkg.Errors))
+		for i, err := range pkg.Errors {
+			errs[i] = err.Error()
+		}
+		return nil, fmt.Errorf("loader: failed to load package %s\ndetail:\n- %s", path, strings.Join(errs, "\n- "))
+	}
+	return pkg, nil
+}
+
+// CheckPackage is like TryLoadPackage, but type-checks the package from the
+// source, e.g. at runtime, where the export data of the package is not
+// available. The type errors are ignored, to return the declarations that
+// can be checked.
+func CheckPackage(path string) (*types.Package, []*ast.File, error) {
+	pkgs, err := LoadPackages(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkg := pkgs[0]
+	if len(pkg.Syntax) == 0 {
+		return nil, nil, fmt.Errorf("loader: failed to load package %s", path)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(pkg.Fset, "source", nil),
+		Error:    func(error) {},
+	}
+	result, _ := conf.Check(pkg.PkgPath, pkg.Fset, pkg.Syntax, nil)
+	return result, pkg.Syntax, nil
+}
+
+// LoadPackages is like LoadPackage, but loads the packages matching the
+// patterns at once, e.g. ./... The packages with errors are returned with
+// their errors, and the syntax of the files that can be parsed.
+func LoadPackages(patterns ...string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedFiles,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("loader: failed to load package: %v", err)
+	}
+
+	for _, pkg := range pkgs {
+		pkg.Fset = token.NewFileSet()
+		for _, file := range pkg.GoFiles {
+			f, err := parser.ParseFile(pkg.Fset, file, nil, parser.ParseComments)
+			if err != nil {
+				// The syntax errors are already reported by the package.
+				if len(pkg.Errors) > 0 {
+					continue
+				}
+				return nil, fmt.Errorf("loader: failed to parse file: %v", err)
+			}
+			pkg.Syntax = append(pkg.Syntax, f)
+		}
+	}
+	return pkgs, nil
+}
+
 func LoadPackageString(hello string) *types.Package {
+	pkg, _ := LoadPackageStringSyntax(hello)
+	return pkg
+}
+
+// LoadPackageStringSyntax is like LoadPackageString, but also returns the
+// syntax with the comments.
+func LoadPackageStringSyntax(hello string) (*types.Package, []*ast.File) {
+	pkg, files, _ := LoadPackageStringFileSet(hello)
+	return pkg, files
+}
+
+// LoadPackageStringFileSet is like LoadPackageStringSyntax, but also returns
+// the file set of the syntax, to report the positions.
+func LoadPackageStringFileSet(hello string) (*types.Package, []*ast.File, *token.FileSet) {
 	fset := token.NewFileSet()
 
 	// Parse the input string, []byte, or io.Reader,
 	// recording position information in fset.
 	// ParseFile returns an *ast.File, a syntax tree.
-	f, err := parser.ParseFile(fset, "hello.go", hello, 0)
+	f, err := parser.ParseFile(fset, "hello.go", hello, parser.ParseComments)
 	if err != nil {
 		log.Fatal(err) // parse error
 	}
@@ -76,5 +159,5 @@ func LoadPackageString(hello string) *types.Package {
 	if err != nil {
 		panic(err)
 	}
-	return pkg
+	return pkg, []*ast.File{f}, fset
 }
@@ -3,30 +3,62 @@ package mapper
 import (
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/token"
 	"go/types"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/alextanhongpin/mapper/loader"
 )
 
+const (
+	ModeInterface = "interface" // Generates the struct implementing the interface.
+	ModeFunc      = "func"      // Generates plain functions.
+)
+
 type Option struct {
-	In      string // The input path, with the file name, e.g. yourpath/yourfile.go
-	Out     string // The output path, with the mapper name, e.g. yourpath/yourfile_gen.go
-	Pkg     *types.Package
-	PkgName string // The pkgName
-	PkgPath string // The pkgPath
-	Suffix  string
-	DryRun  bool
-	Prune   bool
-	Items   []OptionItem
+	In         string // The input path, with the file name, e.g. yourpath/yourfile.go
+	Out        string // The output path, with the mapper name, e.g. yourpath/yourfile_gen.go
+	Pkg        *types.Package
+	Syntax     []*ast.File    // The syntax of the input package, to read the //mapper: directives
+	Fset       *token.FileSet // The positions of the syntax, to report the errors
+	PkgName    string         // The pkgName
+	PkgPath    string         // The pkgPath
+	OutPkgName string         // The pkgName of the output, if it differs from the input
+	OutPkgPath string         // The pkgPath of the output, if it differs from the input
+	Suffix     string
+	DryRun     bool
+	Prune      bool     // Removes the existing files before generating
+	Force      bool     // Generates the files, even if their inputs are unchanged
+	Copy       string   // The copy mode, shallow or deep
+	Refs       bool     // Preserves the shared references and cycles of the source
+	Mode       string   // The generated code, interface or func
+	Register   bool     // Generates the file that registers the private mappers for Map
+	Report     string   // Generates the mapping report, md or json
+	Graph      string   // Generates the dependency graph of the mappers, dot or json
+	Test       bool     // Generates the tests of the public methods
+	Fuzz       bool     // Generates the fuzz targets of the public methods that return error
+	Combine    bool     // Generates the interfaces into the single file Out, instead of a file per interface
+	Converters []string // The packages to load the //mapper:converter functions from
+	Items      []OptionItem
+}
+
+// OutPkg returns the package path and name of the generated code.
+func (o Option) OutPkg() (string, string) {
+	if o.OutPkgPath == "" {
+		return o.PkgPath, o.PkgName
+	}
+	return o.OutPkgPath, o.OutPkgName
 }
 
 type OptionItem struct {
-	Name string
-	Type types.Type
-	Path string
+	Name      string
+	Type      types.Type
+	Path      string
+	Prototype bool // The methods are the //mapper:func vars, which are assigned
 }
 
 type TypeNames struct {
@@ -54,10 +86,11 @@ func (t TypeNames) Items() []string {
 	return result
 }
 
-var typeNames TypeNames
+var typeNames, converterPkgs TypeNames
 
 func init() {
 	typeNames = TypeNames{cache: make(map[string]bool)}
+	converterPkgs = TypeNames{cache: make(map[string]bool)}
 }
 
 type Generator func(opt Option) error
@@ -68,38 +101,104 @@ func New(fn Generator) error {
 	outp := flag.String("out", "", "the output directory")
 	dryRunp := flag.Bool("dry-run", false, "whether to print to stdout or write to file")
 	pkgp := flag.String("pkg", "github.com", "the package prefix to identify the package path, override this if your packages does not reside from github.com")
+	copyp := flag.String("copy", "shallow", "the copy mode, deep copies the slices, maps and pointers with deep")
+	refsp := flag.Bool("refs", false, "preserves the shared references and cycles, by tracking the pointers that are already mapped")
+	modep := flag.String("mode", ModeInterface, "the generated code, plain functions with func")
+	registerp := flag.Bool("register", false, "generates the file that registers the mappers for mapper.Map")
+	reportp := flag.String("report", "", "generates the report of the source of each target field, md or json")
+	graphp := flag.String("graph", "", "generates the dependency graph of the mappers, dot or json")
+	testp := flag.Bool("test", false, "generates the tests of the public methods next to the generated file")
+	combinep := flag.Bool("combine", false, "generates the interfaces of -type into a single file named after the input, instead of a file per interface")
+	fuzzp := flag.Bool("fuzz", false, "generates the fuzz targets of the public methods that return error next to the generated file")
 	prunep := flag.Bool("prune", true, "removing existing file before generating the new code")
+	forcep := flag.Bool("force", false, "generates the files, even if the input hash in their header is unchanged")
 	flag.Var(&typeNames, "type", "the target interface name")
+	flag.Var(&converterPkgs, "converters", "the packages to load the //mapper:converter functions from, in addition to the input package")
 	flag.Parse()
 
+	if *copyp != "shallow" && *copyp != "deep" {
+		panic(fmt.Sprintf("mapper: invalid copy mode %q, must be shallow or deep", *copyp))
+	}
+	if *modep != ModeInterface && *modep != ModeFunc {
+		panic(fmt.Sprintf("mapper: invalid mode %q, must be interface or func", *modep))
+	}
+
+	if *reportp != "" && *reportp != "md" && *reportp != "json" {
+		panic(fmt.Sprintf("mapper: invalid report format %q, must be md or json", *reportp))
+	}
+
+	if *graphp != "" && *graphp != "dot" && *graphp != "json" {
+		panic(fmt.Sprintf("mapper: invalid graph format %q, must be dot or json", *graphp))
+	}
+
+	// Allows mapper ./..., which generates the interfaces annotated with
+	// //mapper:generate in every package matching the patterns.
+	if flag.NArg() > 0 {
+		if len(typeNames.Items()) > 0 {
+			panic("mapper: -type cannot be used with package patterns, annotate the interfaces with //mapper:generate instead")
+		}
+		base := Option{
+			Suffix:     *suffixPtr,
+			DryRun:     *dryRunp,
+			Prune:      *prunep,
+			Force:      *forcep,
+			Copy:       *copyp,
+			Refs:       *refsp,
+			Mode:       *modep,
+			Register:   *registerp,
+			Report:     *reportp,
+			Graph:      *graphp,
+			Test:       *testp,
+			Fuzz:       *fuzzp,
+			Combine:    *combinep,
+			Converters: converterPkgs.Items(),
+		}
+		return discover(fn, base, flag.Args()...)
+	}
+
 	in := loader.FullPath(*inp)
 
 	// Allows -type=Foo,Bar
 	pkg := loader.LoadPackage(loader.PackagePath(*pkgp, in)) // github.com/your-github-username/your-pkg.
 
 	out := loader.FileNameFromTypeName(*inp, *outp, loader.FileName(*inp))
-	opt := Option{
-		//Pkg:     obj.Pkg(),
-		PkgName: pkg.Name,
-		PkgPath: pkg.PkgPath,
-		Out:     out,
-		In:      in,
-		Suffix:  *suffixPtr,
-		DryRun:  *dryRunp,
+
+	// The output may reside in another package, which can only access the
+	// exported members of the input package.
+	var outPkgPath, outPkgName string
+	if outDir := filepath.Dir(out); outDir != filepath.Dir(in) {
+		outPkgPath = loader.PackagePath(*pkgp, outDir)
+		outPkgName = loader.PackageName(*pkgp, outDir)
 	}
 
-	pruneFileIfExists := func(path string) {
-		if *prunep {
-			// File may not exists yet, ignore.
-			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-				fmt.Printf("error removing file %s: %s\n", path, err)
-			}
-		}
+	opt := Option{
+		Pkg:        pkg.Types,
+		Syntax:     pkg.Syntax,
+		Fset:       pkg.Fset,
+		PkgName:    pkg.Name,
+		PkgPath:    pkg.PkgPath,
+		OutPkgName: outPkgName,
+		OutPkgPath: outPkgPath,
+		Out:        out,
+		In:         in,
+		Suffix:     *suffixPtr,
+		DryRun:     *dryRunp,
+		Prune:      *prunep,
+		Force:      *forcep,
+		Copy:       *copyp,
+		Refs:       *refsp,
+		Mode:       *modep,
+		Register:   *registerp,
+		Report:     *reportp,
+		Graph:      *graphp,
+		Test:       *testp,
+		Fuzz:       *fuzzp,
+		Combine:    *combinep,
+		Converters: converterPkgs.Items(),
 	}
 
 	for _, typeName := range typeNames.Items() {
 		path := loader.FileNameFromTypeName(*inp, *outp, typeName)
-		pruneFileIfExists(path)
 
 		obj := pkg.Types.Scope().Lookup(typeName)
 		if obj == nil {
@@ -118,5 +217,17 @@ func New(fn Generator) error {
 		})
 	}
 
+	// Without -type, the //mapper:func vars are generated in the file of the
+	// input.
+	if len(opt.Items) == 0 {
+		if inType, ok := NewPrototypes(pkg.Types, NewDirectives(pkg.Syntax)); ok {
+			opt.Items = append(opt.Items, OptionItem{
+				Path:      out,
+				Type:      inType,
+				Prototype: true,
+			})
+		}
+	}
+
 	return fn(opt)
 }
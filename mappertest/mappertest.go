@@ -0,0 +1,38 @@
+// Package mappertest checks that the generated mappers and mapper.Reflect
+// produce identical results.
+package mappertest
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/google/go-cmp/cmp"
+)
+
+// Conform checks that mapper.Reflect maps src to the same result as the
+// generated mapper.
+func Conform[A, B any](t testing.TB, generated func(A) B, src A, opts ...cmp.Option) {
+	t.Helper()
+
+	ConformE(t, func(a A) (B, error) {
+		return generated(a), nil
+	}, src, opts...)
+}
+
+// ConformE checks that mapper.Reflect maps src to the same result and error as
+// the generated mapper that returns error.
+func ConformE[A, B any](t testing.TB, generated func(A) (B, error), src A, opts ...cmp.Option) {
+	t.Helper()
+
+	want, wantErr := generated(src)
+	got, gotErr := mapper.Reflect[A, B](src)
+	if (wantErr == nil) != (gotErr == nil) {
+		t.Fatalf("want error %v, got %v", wantErr, gotErr)
+	}
+	if wantErr != nil && wantErr.Error() != gotErr.Error() {
+		t.Fatalf("want error %q, got %q", wantErr, gotErr)
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Fatalf("generated and reflect differ (-generated +reflect):\n%s", diff)
+	}
+}
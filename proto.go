@@ -0,0 +1,91 @@
+package mapper
+
+import "go/types"
+
+// protoInternalFields are the unexported fields generated by protoc-gen-go to
+// hold the message internals.
+var protoInternalFields = map[string]bool{
+	"state":         true,
+	"sizeCache":     true,
+	"unknownFields": true,
+}
+
+// IsProtoMessage returns true if the underlying type is a struct generated by
+// protoc-gen-go.
+func IsProtoMessage(T types.Type) bool {
+	U := NewUnderlyingType(T)
+	if U == nil {
+		return false
+	}
+	structType, ok := U.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	return isProtoStruct(structType)
+}
+
+func isProtoStruct(structType *types.Struct) bool {
+	var n int
+	for i := 0; i < structType.NumFields(); i++ {
+		if protoInternalFields[structType.Field(i).Name()] {
+			n++
+		}
+	}
+	return n == len(protoInternalFields)
+}
+
+// IsProtoOneof returns true if the type is the unexported interface generated
+// by protoc-gen-go for a oneof field, e.g. isOrder_Payment.
+func IsProtoOneof(T types.Type) bool {
+	named, ok := T.(*types.Named)
+	if !ok || named.Obj().Exported() {
+		return false
+	}
+	in, ok := named.Underlying().(*types.Interface)
+	if !ok || in.NumMethods() != 1 {
+		return false
+	}
+	return in.Method(0).Name() == named.Obj().Name()
+}
+
+// ProtoOneofVariants returns the wrapper types generated by protoc-gen-go for
+// each member of the oneof, sorted by name. The value is held in the only field
+// of the wrapper type.
+//
+//	type Order_Card struct {
+//		Card *Card `protobuf:"bytes,3,opt,name=card,proto3,oneof"`
+//	}
+func ProtoOneofVariants(T types.Type) []SumVariant {
+	named, ok := T.(*types.Named)
+	if !ok {
+		return nil
+	}
+	in, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+
+	var result []SumVariant
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+		variant, ok := obj.Type().(*types.Named)
+		if !ok || types.IsInterface(variant) {
+			continue
+		}
+		structType, ok := variant.Underlying().(*types.Struct)
+		if !ok || structType.NumFields() != 1 {
+			continue
+		}
+		if types.Implements(types.NewPointer(variant), in) {
+			result = append(result, SumVariant{
+				Type:  types.NewPointer(variant),
+				Field: structType.Field(0),
+			})
+		}
+	}
+	return result
+}
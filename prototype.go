@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+)
+
+// FuncDirective declares a var as the prototype of a generated func, e.g.
+//
+//	//mapper:func
+//	var ToDTO func(User) UserDTO
+const FuncDirective = "func"
+
+// NewPrototypes returns the interface with the //mapper:func vars of the
+// package as methods, or false if there are none.
+func NewPrototypes(pkg *types.Package, directives Directives) (*types.Interface, bool) {
+	var names []string
+	for name := range directives {
+		if directives.Has(name, FuncDirective) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, false
+	}
+	sort.Strings(names)
+
+	methods := make([]*types.Func, len(names))
+	for i, name := range names {
+		obj, ok := pkg.Scope().Lookup(name).(*types.Var)
+		if !ok {
+			panic(fmt.Sprintf("mapper: //mapper:func %s is not a var", name))
+		}
+		sig, ok := obj.Type().(*types.Signature)
+		if !ok {
+			panic(fmt.Sprintf("mapper: //mapper:func %s is not a func, got %s", name, obj.Type()))
+		}
+		methods[i] = types.NewFunc(obj.Pos(), pkg, name, sig)
+	}
+	return types.NewInterfaceType(methods, nil).Complete(), true
+}
@@ -0,0 +1,395 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alextanhongpin/mapper/loader"
+)
+
+var (
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	funcs     sync.Map // map[string]reflect.Value
+)
+
+// RegisterFunc registers the func referenced by the map tag for Reflect, by
+// the name as written in the tag, e.g.
+//
+//	mapper.RegisterFunc("IntToString", IntToString)             // `map:",IntToString"`
+//	mapper.RegisterFunc("strconv/Atoi", strconv.Atoi)           // `map:",strconv/Atoi"`
+//	mapper.RegisterFunc("URLBuilder.Build", URLBuilder{}.Build) // `map:",URLBuilder.Build"`
+//
+// Methods are registered with the method value of the dependency. The names
+// that are not imported are registered for the package of the func, which
+// must be the package of the struct with the tag, like the generated code.
+func RegisterFunc(name string, fn interface{}) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		panic(fmt.Sprintf("mapper: func %q is invalid, got %T", name, fn))
+	}
+	if !strings.Contains(name, "/") {
+		name = path.Join(funcPkgPath(v), name)
+	}
+	funcs.Store(name, v)
+}
+
+// funcPkgPath returns the package path of the func from its symbol name, e.g.
+// github.com/alextanhongpin/mapper/examples.IntToString, or
+// main.URLBuilder.Build-fm for method values.
+func funcPkgPath(fn reflect.Value) string {
+	name := runtime.FuncForPC(fn.Pointer()).Name()
+	// The type args of generic funcs may contain paths.
+	if i := strings.Index(name, "["); i > -1 {
+		name = name[:i]
+	}
+	dir, base := path.Split(name)
+	if i := strings.Index(base, "."); i > -1 {
+		base = base[:i]
+	}
+	return dir + base
+}
+
+// Reflect maps src to B at runtime, without generating the mapper. The map
+// tags of the source and target struct fields have the same semantics as the
+// generated code, and the funcs referenced by the tags must be registered
+// with RegisterFunc. Unexported target fields are skipped.
+func Reflect[A, B any](src A) (B, error) {
+	var dst B
+	if err := reflectValue(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(&src).Elem()); err != nil {
+		var zero B
+		return zero, err
+	}
+	return dst, nil
+}
+
+func reflectValue(dst, src reflect.Value) error {
+	if src.Type() == dst.Type() {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case dst.Kind() == reflect.Ptr:
+		if src.Kind() == reflect.Ptr {
+			if src.IsNil() {
+				return nil
+			}
+			src = src.Elem()
+		}
+		v := reflect.New(dst.Type().Elem())
+		if err := reflectValue(v.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+	case src.Kind() == reflect.Ptr:
+		if src.IsNil() {
+			return nil
+		}
+		return reflectValue(dst, src.Elem())
+	case dst.Kind() == reflect.Slice && (src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		// Like the generated code, nil slices are mapped to empty slices.
+		v := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := reflectValue(v.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(v)
+		return nil
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Struct:
+		return reflectStruct(dst, src)
+	case dst.Kind() != reflect.Interface && src.Type().ConvertibleTo(dst.Type()):
+		// Enums are mapped by the name of their constants, even if their kinds
+		// are different, e.g. int and int32.
+		if ok, err := reflectEnum(dst, src); ok {
+			return err
+		}
+		if src.Kind() != dst.Kind() {
+			return fmt.Errorf("mapper: no conversion found from %s to %s", src.Type(), dst.Type())
+		}
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	case dst.Kind() == reflect.Interface && src.Type().Implements(dst.Type()):
+		dst.Set(src)
+		return nil
+	default:
+		return fmt.Errorf("mapper: no conversion found from %s to %s", src.Type(), dst.Type())
+	}
+}
+
+// reflectEnum maps the enum by the name of its constant, like the generated
+// code, e.g. StatusActive to APIStatusActive. It returns false if src or dst
+// is not an enum, or their package cannot be loaded from the source.
+func reflectEnum(dst, src reflect.Value) (bool, error) {
+	from, fromPkg := enumType(src.Type())
+	to, toPkg := enumType(dst.Type())
+	if from == nil || to == nil {
+		return false, nil
+	}
+
+	// The directives are read from the packages of both enums.
+	enums := NewEnums(fromPkg.pkg, fromPkg.files)
+	enums.add(toPkg.pkg, toPkg.files)
+
+	cases, def := enums.Match(from, to)
+	if len(cases) == 0 {
+		return false, nil
+	}
+	for _, c := range cases {
+		if constant.Compare(c.From.Val(), token.EQL, reflectConst(src)) {
+			return true, setConst(dst, c.To)
+		}
+	}
+	if def != nil {
+		return true, setConst(dst, def)
+	}
+	return true, fmt.Errorf("mapper: unknown %s %v", src.Type(), src)
+}
+
+type enumPackage struct {
+	pkg   *types.Package
+	files []*ast.File
+}
+
+var enumPkgs sync.Map // map[string]*enumPackage
+
+// enumType returns the type of the enum T, checked from the source of its
+// package. The packages are only loaded once, and the main package is loaded
+// from the working directory, e.g. in its tests.
+func enumType(T reflect.Type) (types.Type, *enumPackage) {
+	if T.PkgPath() == "" || T.Name() == "" {
+		return nil, nil
+	}
+
+	v, ok := enumPkgs.Load(T.PkgPath())
+	if !ok {
+		pkgPath := T.PkgPath()
+		if pkgPath == "main" {
+			pkgPath = "."
+		}
+		pkg, files, err := loader.CheckPackage(pkgPath)
+		if err != nil || pkg == nil {
+			// The package is not loaded again, e.g. main or test packages.
+			pkg, files = nil, nil
+		}
+		v, _ = enumPkgs.LoadOrStore(T.PkgPath(), &enumPackage{pkg: pkg, files: files})
+	}
+
+	p := v.(*enumPackage)
+	if p.pkg == nil {
+		return nil, nil
+	}
+	obj, ok := p.pkg.Scope().Lookup(T.Name()).(*types.TypeName)
+	if !ok {
+		return nil, nil
+	}
+	return obj.Type(), p
+}
+
+// reflectConst returns the constant value of the basic value v.
+func reflectConst(v reflect.Value) constant.Value {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return constant.MakeInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return constant.MakeUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return constant.MakeFloat64(v.Float())
+	case reflect.String:
+		return constant.MakeString(v.String())
+	case reflect.Bool:
+		return constant.MakeBool(v.Bool())
+	default:
+		return constant.MakeUnknown()
+	}
+}
+
+// setConst sets the value of the constant c to dst.
+func setConst(dst reflect.Value, c *types.Const) error {
+	val := c.Val()
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, _ := constant.Int64Val(constant.ToInt(val))
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, _ := constant.Uint64Val(constant.ToInt(val))
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, _ := constant.Float64Val(constant.ToFloat(val))
+		dst.SetFloat(f)
+	case reflect.String:
+		dst.SetString(constant.StringVal(val))
+	case reflect.Bool:
+		dst.SetBool(constant.BoolVal(val))
+	default:
+		return fmt.Errorf("mapper: unsupported enum %s", dst.Type())
+	}
+	return nil
+}
+
+// reflectStruct maps the fields of src to dst. Like the generated code, the
+// source fields are exposed by their alias, and the target fields are mapped
+// from the source field, or the source method with the same name.
+func reflectStruct(dst, src reflect.Value) error {
+	// The copy is addressable, to call the methods with pointer receiver.
+	ptr := reflect.New(src.Type())
+	ptr.Elem().Set(src)
+
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Type().Field(i)
+		key := field.Name
+		if tag, ok := NewTag(string(field.Tag)); ok {
+			if tag.Ignore {
+				continue
+			}
+			if tag.IsAlias() {
+				key = tag.Name
+			}
+		}
+		// Like the generator, the aliases must not conflict.
+		if prev, ok := fields[key]; ok {
+			return fmt.Errorf("mapper: fields %s and %s of %s are both exposed as %s", prev.Name, field.Name, src.Type(), key)
+		}
+		fields[key] = field
+	}
+
+	// The fields are mapped in the order of their names, so that the first
+	// error returned is the same as the generated code.
+	order := make([]int, dst.NumField())
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return dst.Type().Field(order[i]).Name < dst.Type().Field(order[j]).Name
+	})
+
+	for _, i := range order {
+		field := dst.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, _ := NewTag(string(field.Tag))
+		if tag != nil && tag.Ignore {
+			continue
+		}
+		key := field.Name
+		if tag != nil && tag.IsAlias() {
+			key = tag.Name
+		}
+
+		var (
+			value  reflect.Value
+			srcTag *Tag
+		)
+		if method := ptr.MethodByName(key); method.IsValid() {
+			v, err := call(method, nil)
+			if err != nil {
+				return err
+			}
+			value = v
+		} else if sf, ok := fields[key]; ok && sf.PkgPath == "" {
+			value = src.FieldByIndex(sf.Index)
+			srcTag, _ = NewTag(string(sf.Tag))
+		} else {
+			return fmt.Errorf("mapper: no mapping found for %q", field.Name)
+		}
+
+		if merged := MergeTag(srcTag, tag); merged != nil && merged.HasFunc() {
+			// The func of the tag is declared in the package of its struct.
+			pkgPath := dst.Type().PkgPath()
+			if merged != tag {
+				pkgPath = src.Type().PkgPath()
+			}
+			v, err := callTag(merged, pkgPath, value)
+			if err != nil {
+				return err
+			}
+			value = v
+		}
+
+		if err := reflectValue(dst.Field(i), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callTag calls the func referenced by the tag with the value, or each
+// element of the value if the func accepts the element. The funcs that are
+// not imported are looked up in the package path.
+func callTag(tag *Tag, pkgPath string, value reflect.Value) (reflect.Value, error) {
+	name := tag.Func
+	if tag.IsMethod() {
+		name = tag.TypeName + "." + tag.Func
+	}
+	if tag.IsImported() {
+		name = path.Join(tag.PkgPath, name)
+	}
+
+	key := name
+	if !tag.IsImported() {
+		key = path.Join(pkgPath, name)
+	}
+	v, ok := funcs.Load(key)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("mapper: func %q of tag %q is not registered", name, tag.Tag)
+	}
+	fn := v.(reflect.Value)
+	if fn.Type().NumIn() != 1 {
+		return reflect.Value{}, fmt.Errorf("mapper: func %q of tag %q must accept one arg", name, tag.Tag)
+	}
+
+	if value.Type().AssignableTo(fn.Type().In(0)) || value.Kind() != reflect.Slice {
+		return call(fn, []reflect.Value{value})
+	}
+
+	result := reflect.MakeSlice(reflect.SliceOf(fn.Type().Out(0)), value.Len(), value.Len())
+	for i := 0; i < value.Len(); i++ {
+		out, err := call(fn, []reflect.Value{value.Index(i)})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.Index(i).Set(out)
+	}
+	return result, nil
+}
+
+// call calls the func that returns the value, and optionally error.
+func call(fn reflect.Value, args []reflect.Value) (reflect.Value, error) {
+	if n := fn.Type().NumIn(); n != len(args) {
+		return reflect.Value{}, fmt.Errorf("mapper: func %s requires %d args, got %d", fn.Type(), n, len(args))
+	}
+	for i, arg := range args {
+		// Like the generator, the arg is not converted, e.g. int to string by
+		// rune, or a named type to its underlying type.
+		if !arg.Type().AssignableTo(fn.Type().In(i)) {
+			return reflect.Value{}, fmt.Errorf("mapper: func %s does not accept %s", fn.Type(), arg.Type())
+		}
+	}
+
+	out := fn.Call(args)
+	switch {
+	case len(out) == 1:
+		return out[0], nil
+	case len(out) == 2 && fn.Type().Out(1) == errorType:
+		if err, _ := out[1].Interface().(error); err != nil {
+			return reflect.Value{}, err
+		}
+		return out[0], nil
+	default:
+		return reflect.Value{}, errors.New("mapper: func must return the value, and optionally error")
+	}
+}
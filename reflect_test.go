@@ -0,0 +1,129 @@
+package mapper_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+type reflectA struct {
+	ID       int `map:"ExternalID"`
+	Name     string
+	Password string `map:"-"`
+}
+
+type reflectB struct {
+	ExternalID int
+	FullName   *string `map:"Name"`
+	Secret     string  `map:"-"`
+}
+
+func TestReflect(t *testing.T) {
+	b, err := mapper.Reflect[reflectA, reflectB](reflectA{ID: 1, Name: "john", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ExternalID != 1 || b.FullName == nil || *b.FullName != "john" || b.Secret != "" {
+		t.Fatalf("unexpected result %+v", b)
+	}
+}
+
+func TestReflectNotRegistered(t *testing.T) {
+	type c struct {
+		Name string `map:",NotRegistered"`
+	}
+	_, err := mapper.Reflect[reflectA, c](reflectA{})
+	if err == nil || err.Error() != `mapper: func "NotRegistered" of tag "map:\",NotRegistered\"" is not registered` {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+type reflectCode int
+
+func TestReflectTagFuncParam(t *testing.T) {
+	mapper.RegisterFunc("strconv/Itoa", strconv.Itoa)
+	mapper.RegisterFunc("strings/ToUpper", strings.ToUpper)
+
+	type src struct {
+		ID   int
+		Code reflectCode
+	}
+	type dst struct {
+		ID string `map:",strconv/Itoa"`
+	}
+	b, err := mapper.Reflect[src, dst](src{ID: 65})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != "65" {
+		t.Fatalf("want id %q, got %q", "65", b.ID)
+	}
+
+	// The args are not converted, which the generator rejects with "input
+	// type does not match func arg".
+	type upper struct {
+		ID string `map:",strings/ToUpper"`
+	}
+	_, err = mapper.Reflect[src, upper](src{ID: 65})
+	if err == nil || err.Error() != "mapper: func func(string) string does not accept int" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	type named struct {
+		Code string `map:",strconv/Itoa"`
+	}
+	_, err = mapper.Reflect[src, named](src{Code: 65})
+	if err == nil || err.Error() != "mapper: func func(int) string does not accept mapper_test.reflectCode" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func reflectTitle(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func TestReflectRegisterFuncPackage(t *testing.T) {
+	type dst struct {
+		Name string `map:",reflectTitle"`
+	}
+
+	// The func is registered for the package of the func, and not found for
+	// the struct in this package.
+	mapper.RegisterFunc("reflectTitle", strings.ToUpper)
+	_, err := mapper.Reflect[reflectA, dst](reflectA{Name: "john"})
+	if err == nil || err.Error() != `mapper: func "reflectTitle" of tag "map:\",reflectTitle\"" is not registered` {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	mapper.RegisterFunc("reflectTitle", reflectTitle)
+	b, err := mapper.Reflect[reflectA, dst](reflectA{Name: "john"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Name != "John" {
+		t.Fatalf("want name %q, got %q", "John", b.Name)
+	}
+}
+
+func TestReflectNilSlice(t *testing.T) {
+	b, err := mapper.Reflect[[]reflectA, []reflectB](nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b == nil || len(b) != 0 {
+		t.Fatalf("want empty slice, got %#v", b)
+	}
+}
+
+func TestReflectAliasConflict(t *testing.T) {
+	type src struct {
+		ID   int `map:"ExternalID"`
+		Code int `map:"ExternalID"`
+	}
+	_, err := mapper.Reflect[src, reflectB](src{})
+	if err == nil || err.Error() != "mapper: fields ID and Code of mapper_test.src are both exposed as ExternalID" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrNotRegistered is returned by Map when no mapper is registered for the
+// type pair.
+var ErrNotRegistered = errors.New("mapper: not registered")
+
+type typePair struct {
+	from, to reflect.Type
+}
+
+var registry sync.Map // map[typePair]interface{}
+
+// Register registers the mapper from A to B, which is looked up by Map. The
+// generated registration file registers the private mappers, e.g.
+//
+//	mapper.Register(func(a0 A) (B, error) {
+//		return m.mapMainAToMainB(a0), nil
+//	})
+//
+// Registering the same type pair again replaces the mapper.
+func Register[A, B any](fn func(A) (B, error)) {
+	registry.Store(newTypePair[A, B](), fn)
+}
+
+// Map maps src to B with the mapper registered for A to B.
+func Map[A, B any](src A) (B, error) {
+	fn, err := lookup[A, B]()
+	if err != nil {
+		var zero B
+		return zero, err
+	}
+	return fn(src)
+}
+
+// MapSlice maps each element of src to B with the mapper registered for A to
+// B. A nil slice returns nil.
+func MapSlice[A, B any](src []A) ([]B, error) {
+	fn, err := lookup[A, B]()
+	if err != nil {
+		return nil, err
+	}
+	if src == nil {
+		return nil, nil
+	}
+
+	result := make([]B, len(src))
+	for i, each := range src {
+		b, err := fn(each)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = b
+	}
+	return result, nil
+}
+
+func lookup[A, B any]() (func(A) (B, error), error) {
+	pair := newTypePair[A, B]()
+	fn, ok := registry.Load(pair)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s to %s\nhint: generate the mapper with -register, and call the Register func of the generated mapper", ErrNotRegistered, pair.from, pair.to)
+	}
+	return fn.(func(A) (B, error)), nil
+}
+
+func newTypePair[A, B any]() typePair {
+	return typePair{
+		from: reflect.TypeOf((*A)(nil)).Elem(),
+		to:   reflect.TypeOf((*B)(nil)).Elem(),
+	}
+}
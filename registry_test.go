@@ -0,0 +1,41 @@
+package mapper_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+)
+
+type registryA struct{ ID int }
+type registryB struct{ ID string }
+
+func TestMap(t *testing.T) {
+	mapper.Register(func(a registryA) (registryB, error) {
+		return registryB{ID: strconv.Itoa(a.ID)}, nil
+	})
+
+	b, err := mapper.Map[registryA, registryB](registryA{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != "1" {
+		t.Fatalf("want %q, got %q", "1", b.ID)
+	}
+
+	bs, err := mapper.MapSlice[registryA, registryB]([]registryA{{ID: 1}, {ID: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bs) != 2 || bs[1].ID != "2" {
+		t.Fatalf("want 2 mapped, got %v", bs)
+	}
+}
+
+func TestMapNotRegistered(t *testing.T) {
+	_, err := mapper.Map[registryB, registryA](registryB{})
+	if !errors.Is(err, mapper.ErrNotRegistered) {
+		t.Fatalf("want ErrNotRegistered, got %v", err)
+	}
+}
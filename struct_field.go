@@ -1,7 +1,9 @@
 package mapper
 
 import (
+	"fmt"
 	"go/types"
+	"sort"
 )
 
 // StructField for the example below.
@@ -19,6 +21,12 @@ type StructField struct {
 	Type     types.Type
 }
 
+// IsAccessibleFrom returns true if the field can be referenced by code in the
+// package pkgPath.
+func (s StructField) IsAccessibleFrom(pkgPath string) bool {
+	return s.Exported || s.PkgPath == pkgPath
+}
+
 type StructFields map[string]StructField
 
 func (s StructFields) WithTags() StructFields {
@@ -60,12 +68,52 @@ func (s StructFields) WithTags() StructFields {
 	return result
 }
 
+// Outgoing returns the fields of a source struct keyed by the name they are
+// exposed as. A source field tagged `map:"PublicName"` is exposed as
+// PublicName, and fields tagged `map:"-"` are excluded.
+//
+// The same struct may be the target of another method, where several fields
+// may alias the same source field (see WithTags). Such fields conflict when
+// the struct is the source, and return error.
+func (s StructFields) Outgoing() (StructFields, error) {
+	fields := make([]StructField, 0, len(s))
+	for _, val := range s {
+		if val.Tag != nil && val.Tag.Ignore {
+			continue
+		}
+		fields = append(fields, val)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Ordinal < fields[j].Ordinal
+	})
+
+	result := make(StructFields)
+	for _, val := range fields {
+		key := val.Name
+		if val.Tag != nil && val.Tag.IsAlias() {
+			key = val.Tag.Name
+		}
+		if prev, exists := result[key]; exists {
+			return nil, fmt.Errorf("fields %s and %s are both exposed as %s", prev.Name, val.Name, key)
+		}
+		result[key] = val
+	}
+	return result, nil
+}
+
 func newStructFields(structType *types.Struct) StructFields {
+	isProto := isProtoStruct(structType)
+
 	fields := make(StructFields)
 	for i := 0; i < structType.NumFields(); i++ {
 		field := structType.Field(i)
 		key := field.Name()
 
+		// Skip the message internals of protobuf messages.
+		if isProto && protoInternalFields[key] {
+			continue
+		}
+
 		tag, _ := NewTag(structType.Tag(i))
 
 		fields[key] = StructField{
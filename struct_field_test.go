@@ -0,0 +1,61 @@
+package mapper_test
+
+import (
+	"testing"
+
+	"github.com/alextanhongpin/mapper"
+	"github.com/alextanhongpin/mapper/loader"
+)
+
+func TestStructFieldsOutgoing(t *testing.T) {
+	pkg := loader.LoadPackageString(`package main
+
+type ProductSummary struct {
+	Items      bool
+	TotalCount int64 ` + "`map:\"Count,CountItems\"`" + `
+	Status     string ` + "`map:\"State\"`" + `
+	Internal   string ` + "`map:\"-\"`" + `
+}`)
+
+	T := pkg.Scope().Lookup("ProductSummary").Type()
+	fields, err := mapper.NewStructFields(T).Outgoing()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"Items": "Items",
+		"Count": "TotalCount",
+		"State": "Status",
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("want %d fields, got %d: %v", len(want), len(fields), fields)
+	}
+	for key, name := range want {
+		field, ok := fields[key]
+		if !ok {
+			t.Errorf("missing field %q", key)
+			continue
+		}
+		if field.Name != name {
+			t.Errorf("want %q exposed as %q, got %q", name, key, field.Name)
+		}
+	}
+}
+
+func TestStructFieldsOutgoingConflict(t *testing.T) {
+	pkg := loader.LoadPackageString(`package main
+
+type ProductSummary struct {
+	Items      bool
+	TotalCount int64 ` + "`map:\"Items,CountItems\"`" + `
+	Status     string ` + "`map:\"State\"`" + `
+	Code       string ` + "`map:\"State\"`" + `
+}`)
+
+	T := pkg.Scope().Lookup("ProductSummary").Type()
+	_, err := mapper.NewStructFields(T).Outgoing()
+	if err == nil || err.Error() != "fields Items and TotalCount are both exposed as Items" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
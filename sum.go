@@ -0,0 +1,63 @@
+package mapper
+
+import "go/types"
+
+// SumVariant is a case of the type switch over an interface, e.g. Card for
+// PaymentMethod. For protobuf oneof, the value is held in the field of the
+// wrapper type.
+type SumVariant struct {
+	Type  types.Type // The type of the case, e.g. Card or *pb.Order_Card
+	Field *types.Var // The field holding the value, e.g. Card, only for oneof
+}
+
+// Value returns the type of the value to be mapped.
+func (v SumVariant) Value() types.Type {
+	if v.Field != nil {
+		return v.Field.Type()
+	}
+	return v.Type
+}
+
+// SumVariants returns the types in the package of the interface T that
+// implements T, sorted by name. If the value implements T, both the value and
+// the pointer are returned, since either can be held by T. The pointer is used
+// if only the pointer implements T.
+func SumVariants(T types.Type) []SumVariant {
+	if IsProtoOneof(T) {
+		return ProtoOneofVariants(T)
+	}
+
+	named, ok := T.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	in, ok := named.Underlying().(*types.Interface)
+	if !ok || in.Empty() {
+		return nil
+	}
+
+	var result []SumVariant
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() || types.IsInterface(obj.Type()) {
+			continue
+		}
+		switch {
+		case types.Implements(obj.Type(), in):
+			result = append(result,
+				SumVariant{Type: obj.Type()},
+				SumVariant{Type: types.NewPointer(obj.Type())},
+			)
+		case types.Implements(types.NewPointer(obj.Type()), in):
+			result = append(result, SumVariant{Type: types.NewPointer(obj.Type())})
+		}
+	}
+	return result
+}
+
+// IsSumType returns true if the type is an interface with implementations in
+// its package.
+func IsSumType(T types.Type) bool {
+	return len(SumVariants(T)) > 0
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/alextanhongpin/mapper/loader"
 )
 
+const tagCopy = "copy"
+
 var tagRe *regexp.Regexp
 var tagPatternRe *regexp.Regexp
 
@@ -56,6 +58,16 @@ func NewTag(tag string) (*Tag, bool) {
 	pkgPath, expr := path.Split(matches[0][3])
 	pkgPath = strings.TrimRight(pkgPath, "/") // Removes trailing slash
 
+	// The copy option deep copies the field, e.g. `map:",copy"`.
+	if pkgPath == "" && expr == tagCopy {
+		return &Tag{
+			Name:          name,
+			FieldOrMethod: fieldOrMethod,
+			Tag:           tag,
+			Copy:          true,
+		}, true
+	}
+
 	var typeName, fn string
 	parts := strings.Split(expr, ".")
 
@@ -97,6 +109,29 @@ type Tag struct {
 	Func   string `example:"YourMethod"`
 	Tag    string
 	Ignore bool
+	Copy   bool // Deep copies the slices, maps and pointers of the field.
+}
+
+// MergeTag returns the tag that applies when a source field tagged with src
+// is mapped to a target field tagged with dst. The target tag takes
+// precedence, and the source tag only supplies the transformation function
+// when the target does not declare one.
+func MergeTag(src, dst *Tag) *Tag {
+	if src == nil || !src.HasFunc() {
+		return dst
+	}
+	if dst != nil && dst.HasFunc() {
+		return dst
+	}
+
+	tag := *src
+	tag.Name = ""
+	tag.FieldOrMethod = 'f'
+	if dst != nil {
+		tag.Name = dst.Name
+		tag.FieldOrMethod = dst.FieldOrMethod
+	}
+	return &tag
 }
 
 func (t Tag) HasFunc() bool {
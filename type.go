@@ -42,3 +42,13 @@ func IsSlice(T types.Type) bool {
 	_, ok := T.(*types.Slice)
 	return ok
 }
+
+// IsAccessibleFrom returns true if the underlying named type can be referenced
+// by code in the package pkgPath.
+func IsAccessibleFrom(T types.Type, pkgPath string) bool {
+	obj := NewUnderlyingVisitor(T).Obj()
+	if obj == nil || obj.Pkg() == nil {
+		return true
+	}
+	return obj.Exported() || obj.Pkg().Path() == pkgPath
+}